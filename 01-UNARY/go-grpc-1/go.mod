@@ -1,11 +1,13 @@
 module grpc1
 
-go 1.22.0
+go 1.22.5
 
 require (
 	github.com/gin-gonic/gin v1.10.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240528184218-531527333157
 	google.golang.org/grpc v1.65.0
 	google.golang.org/protobuf v1.34.2
+	grpc-2 v0.0.0
 )
 
 require (
@@ -33,6 +35,7 @@ require (
 	golang.org/x/net v0.25.0 // indirect
 	golang.org/x/sys v0.20.0 // indirect
 	golang.org/x/text v0.15.0 // indirect
-	google.golang.org/genproto/googleapis/rpc v0.0.0-20240528184218-531527333157 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace grpc-2 => ../go-grpc-2
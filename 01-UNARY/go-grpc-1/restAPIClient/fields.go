@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// setField sets the field of message named name, by its JSON or proto name,
+// from the text of a path or query parameter. Scalars, enums (by name or
+// number), timestamps (RFC 3339) and string maps written as "name[key]" are
+// supported.
+func setField(message protoreflect.Message, name string, value string) error {
+	key := ""
+	if open := strings.IndexByte(name, '['); open >= 0 && strings.HasSuffix(name, "]") {
+		name, key = name[:open], name[open+1:len(name)-1]
+	}
+
+	fields := message.Descriptor().Fields()
+	field := fields.ByJSONName(name)
+	if field == nil {
+		field = fields.ByName(protoreflect.Name(name))
+	}
+	if field == nil {
+		return fmt.Errorf("unknown field %q", name)
+	}
+
+	if field.IsMap() {
+		if key == "" || field.MapKey().Kind() != protoreflect.StringKind || field.MapValue().Kind() != protoreflect.StringKind {
+			return fmt.Errorf("field %q must be set as %s[key]", name, name)
+		}
+		message.Mutable(field).Map().Set(protoreflect.ValueOfString(key).MapKey(), protoreflect.ValueOfString(value))
+		return nil
+	}
+	if key != "" || field.IsList() {
+		return fmt.Errorf("field %q cannot be set from a parameter", name)
+	}
+
+	parsed, err := parseValue(field, value)
+	if err != nil {
+		return fmt.Errorf("invalid value %q for field %q: %w", value, name, err)
+	}
+	message.Set(field, parsed)
+	return nil
+}
+
+func parseValue(field protoreflect.FieldDescriptor, value string) (protoreflect.Value, error) {
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(value), nil
+	case protoreflect.BoolKind:
+		parsed, err := strconv.ParseBool(value)
+		return protoreflect.ValueOfBool(parsed), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		parsed, err := strconv.ParseInt(value, 10, 32)
+		return protoreflect.ValueOfInt32(int32(parsed)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		return protoreflect.ValueOfInt64(parsed), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(parsed)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		parsed, err := strconv.ParseUint(value, 10, 64)
+		return protoreflect.ValueOfUint64(parsed), err
+	case protoreflect.FloatKind:
+		parsed, err := strconv.ParseFloat(value, 32)
+		return protoreflect.ValueOfFloat32(float32(parsed)), err
+	case protoreflect.DoubleKind:
+		parsed, err := strconv.ParseFloat(value, 64)
+		return protoreflect.ValueOfFloat64(parsed), err
+	case protoreflect.EnumKind:
+		if enumValue := field.Enum().Values().ByName(protoreflect.Name(value)); enumValue != nil {
+			return protoreflect.ValueOfEnum(enumValue.Number()), nil
+		}
+		parsed, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || field.Enum().Values().ByNumber(protoreflect.EnumNumber(parsed)) == nil {
+			return protoreflect.Value{}, fmt.Errorf("not a value of %s", field.Enum().FullName())
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(parsed)), nil
+	case protoreflect.MessageKind:
+		if field.Message().FullName() == "google.protobuf.Timestamp" {
+			parsed, err := time.Parse(time.RFC3339Nano, value)
+			if err != nil {
+				return protoreflect.Value{}, err
+			}
+			return protoreflect.ValueOfMessage(timestamppb.New(parsed).ProtoReflect()), nil
+		}
+	}
+	return protoreflect.Value{}, fmt.Errorf("%s fields are not supported", field.Kind())
+}
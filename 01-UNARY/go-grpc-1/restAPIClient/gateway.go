@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	_ "google.golang.org/genproto/googleapis/rpc/errdetails" // lets error details be encoded as JSON
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// metadataHeaderPrefix marks the response headers that carry gRPC header metadata
+const metadataHeaderPrefix = "Grpc-Metadata-"
+
+// skippedHeaders are the request headers that describe the HTTP connection
+// rather than the call, so they are not passed on as gRPC metadata
+var skippedHeaders = map[string]bool{
+	"connection":        true,
+	"content-length":    true,
+	"content-type":      true,
+	"grpc-timeout":      true,
+	"host":              true,
+	"keep-alive":        true,
+	"te":                true,
+	"trailer":           true,
+	"transfer-encoding": true,
+	"upgrade":           true,
+}
+
+var (
+	marshalOptions   = protojson.MarshalOptions{EmitUnpopulated: true}
+	unmarshalOptions = protojson.UnmarshalOptions{}
+)
+
+// gateway translates REST requests to unary gRPC calls
+type gateway struct {
+	// timeout bounds calls whose request has no Grpc-Timeout header
+	timeout time.Duration
+}
+
+// unary returns a handler that builds the request message of call from the
+// JSON body, the query and the path parameters, in that order of precedence,
+// and writes the response message as JSON
+func unary[Req proto.Message, Res proto.Message](gw *gateway, call func(context.Context, Req, ...grpc.CallOption) (Res, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var zero Req
+		req := zero.ProtoReflect().New().Interface().(Req)
+
+		err := decodeRequest(c, req)
+		if err != nil {
+			writeError(c, status.New(codes.InvalidArgument, err.Error()))
+			return
+		}
+
+		ctx, cancel, err := gw.callContext(c)
+		if err != nil {
+			writeError(c, status.New(codes.InvalidArgument, err.Error()))
+			return
+		}
+		defer cancel()
+
+		var header metadata.MD
+		res, err := call(ctx, req, grpc.Header(&header))
+		for key, values := range header {
+			if key == "content-type" {
+				continue
+			}
+			for _, value := range values {
+				c.Writer.Header().Add(metadataHeaderPrefix+key, value)
+			}
+		}
+		if err != nil {
+			writeError(c, status.Convert(err))
+			return
+		}
+
+		data, err := marshalOptions.Marshal(res)
+		if err != nil {
+			writeError(c, status.Newf(codes.Internal, "cannot encode response: %v", err))
+			return
+		}
+		c.Data(http.StatusOK, "application/json", data)
+	}
+}
+
+func decodeRequest(c *gin.Context, req proto.Message) error {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return fmt.Errorf("cannot read body: %w", err)
+	}
+	if len(strings.TrimSpace(string(body))) > 0 {
+		err = unmarshalOptions.Unmarshal(body, req)
+		if err != nil {
+			return fmt.Errorf("cannot decode body: %w", err)
+		}
+	}
+
+	message := req.ProtoReflect()
+	for name, values := range c.Request.URL.Query() {
+		for _, value := range values {
+			err = setField(message, name, value)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	for _, param := range c.Params {
+		err = setField(message, param.Key, param.Value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// callContext derives the context of the gRPC call from the HTTP request: it
+// is canceled when the client goes away, ends at the deadline the client sent
+// in a Grpc-Timeout header, and carries the request headers as metadata
+func (gw *gateway) callContext(c *gin.Context) (context.Context, context.CancelFunc, error) {
+	timeout := gw.timeout
+	if value := c.GetHeader("Grpc-Timeout"); value != "" {
+		parsed, err := parseTimeout(value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid Grpc-Timeout header: %w", err)
+		}
+		timeout = parsed
+	}
+
+	md := metadata.MD{}
+	for name, values := range c.Request.Header {
+		key := strings.ToLower(name)
+		if skippedHeaders[key] || strings.HasPrefix(key, "grpc-") || strings.HasPrefix(key, "proxy-") {
+			continue
+		}
+		md.Append(key, values...)
+	}
+	md.Append("x-forwarded-for", c.ClientIP())
+	md.Set("x-forwarded-host", c.Request.Host)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+	return metadata.NewOutgoingContext(ctx, md), cancel, nil
+}
+
+// parseTimeout parses a timeout in the gRPC wire format, such as "500m" for
+// 500 milliseconds or "2S" for 2 seconds
+func parseTimeout(value string) (time.Duration, error) {
+	units := map[byte]time.Duration{
+		'H': time.Hour,
+		'M': time.Minute,
+		'S': time.Second,
+		'm': time.Millisecond,
+		'u': time.Microsecond,
+		'n': time.Nanosecond,
+	}
+
+	if len(value) < 2 || len(value) > 9 {
+		return 0, fmt.Errorf("%q is not 1 to 8 digits followed by a unit", value)
+	}
+	unit, ok := units[value[len(value)-1]]
+	if !ok {
+		return 0, fmt.Errorf("%q has no unit among H, M, S, m, u and n", value)
+	}
+	amount, err := strconv.ParseUint(value[:len(value)-1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not 1 to 8 digits followed by a unit", value)
+	}
+	return time.Duration(amount) * unit, nil
+}
+
+// writeError writes st as a google.rpc.Status JSON object, with the HTTP
+// status matching its code
+func writeError(c *gin.Context, st *status.Status) {
+	data, err := marshalOptions.Marshal(st.Proto())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": codes.Internal, "message": err.Error()})
+		return
+	}
+	c.Data(httpStatus(st.Code()), "application/json", data)
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	proto "grpc1/helloGrpc"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// helloServer answers with the metadata and deadline of the call it received
+type helloServer struct {
+	proto.UnimplementedHelloWorldServer
+}
+
+func (s *helloServer) SayHelloWorld(ctx context.Context, req *proto.HelloWorldRequest) (*proto.HelloWorldResponse, error) {
+	switch req.Name {
+	case "":
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	case "missing":
+		return nil, status.Error(codes.NotFound, "no such name")
+	case "slow":
+		<-ctx.Done()
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	grpc.SetHeader(ctx, metadata.Pairs("greeted", req.Name))
+	return &proto.HelloWorldResponse{Message: "Hello " + req.Name + " from " + strings.Join(md.Get("x-tenant"), ",")}, nil
+}
+
+func newTestRouter(t *testing.T) *gin.Engine {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	proto.RegisterHelloWorldServer(server, &helloServer{})
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	err = register(router, helloWorldRoutes(&gateway{timeout: time.Second}, proto.NewHelloWorldClient(conn)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return router
+}
+
+func TestGateway(t *testing.T) {
+	router := newTestRouter(t)
+
+	testCases := []struct {
+		name    string
+		method  string
+		path    string
+		body    string
+		headers map[string]string
+		status  int
+		// contains is expected in the response body
+		contains string
+	}{
+		{name: "path", method: http.MethodGet, path: "/hello/Mark", headers: map[string]string{"X-Tenant": "acme"}, status: http.StatusOK, contains: `"message":"Hello Mark from acme"`},
+		{name: "body", method: http.MethodPost, path: "/v1/hello", body: `{"name":"Ann"}`, status: http.StatusOK, contains: `"message":"Hello Ann from "`},
+		{name: "query", method: http.MethodPost, path: "/v1/hello?name=Bob", status: http.StatusOK, contains: `"Hello Bob from "`},
+		{name: "invalid_argument", method: http.MethodPost, path: "/v1/hello", body: `{}`, status: http.StatusBadRequest, contains: `"message":"name is required"`},
+		{name: "not_found", method: http.MethodGet, path: "/hello/missing", status: http.StatusNotFound, contains: `"code":5`},
+		{name: "unknown_field", method: http.MethodPost, path: "/v1/hello", body: `{"nom":"Ann"}`, status: http.StatusBadRequest},
+		{name: "deadline", method: http.MethodGet, path: "/hello/slow", headers: map[string]string{"Grpc-Timeout": "50m"}, status: http.StatusGatewayTimeout},
+		{name: "invalid_deadline", method: http.MethodGet, path: "/hello/slow", headers: map[string]string{"Grpc-Timeout": "soon"}, status: http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			for key, value := range tc.headers {
+				request.Header.Set(key, value)
+			}
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			if recorder.Code != tc.status {
+				t.Fatalf("got status %d, expected %d: %s", recorder.Code, tc.status, recorder.Body)
+			}
+			if !json.Valid(recorder.Body.Bytes()) {
+				t.Fatalf("body is not JSON: %s", recorder.Body)
+			}
+			if !strings.Contains(recorder.Body.String(), tc.contains) {
+				t.Fatalf("body %s does not contain %s", recorder.Body, tc.contains)
+			}
+		})
+	}
+}
+
+func TestGatewayResponseMetadata(t *testing.T) {
+	router := newTestRouter(t)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/hello/Mark", nil))
+
+	if got := recorder.Header().Get("Grpc-Metadata-Greeted"); got != "Mark" {
+		t.Fatalf("got Grpc-Metadata-Greeted %q, expected %q", got, "Mark")
+	}
+}
+
+func TestHTTPStatus(t *testing.T) {
+	expected := map[codes.Code]int{
+		codes.OK:                 http.StatusOK,
+		codes.InvalidArgument:    http.StatusBadRequest,
+		codes.FailedPrecondition: http.StatusBadRequest,
+		codes.NotFound:           http.StatusNotFound,
+		codes.AlreadyExists:      http.StatusConflict,
+		codes.ResourceExhausted:  http.StatusTooManyRequests,
+		codes.Unavailable:        http.StatusServiceUnavailable,
+		codes.Internal:           http.StatusInternalServerError,
+	}
+	for code, httpCode := range expected {
+		if got := httpStatus(code); got != httpCode {
+			t.Errorf("httpStatus(%s) = %d, expected %d", code, got, httpCode)
+		}
+	}
+}
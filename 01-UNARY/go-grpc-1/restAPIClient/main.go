@@ -1,40 +1,43 @@
 package main
 
 import (
-	"context"
+	"flag"
+	invoice "grpc-2/invoice"
 	proto "grpc1/helloGrpc"
 	"log"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
-var client proto.HelloWorldClient
-
 func main() {
-	conn, err := grpc.NewClient("localhost:9000", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	address := flag.String("address", ":8000", "address the REST gateway listens on")
+	helloAddress := flag.String("hello-address", "localhost:9000", "address of the HelloWorld gRPC server")
+	invoiceAddress := flag.String("invoice-address", "localhost:50051", "address of the Invoice gRPC server")
+	timeout := flag.Duration("timeout", 30*time.Second, "deadline of calls whose request has no Grpc-Timeout header")
+	flag.Parse()
+
+	helloConn, err := grpc.NewClient(*helloAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalln(err)
+	}
+	invoiceConn, err := grpc.NewClient(*invoiceAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
 		log.Fatalln(err)
 	}
-	client = proto.NewHelloWorldClient(conn)
-	router := gin.Default()
-	router.GET("/hello/:message", clientConnectionServer)
-	router.Run(":8000")
-}
 
-func clientConnectionServer(c *gin.Context) {
-	message := c.Param("message")
+	gw := &gateway{timeout: *timeout}
+	routes := append(
+		helloWorldRoutes(gw, proto.NewHelloWorldClient(helloConn)),
+		invoiceRoutes(gw, invoice.NewInvoiceClient(invoiceConn))...,
+	)
 
-	req := &proto.HelloWorldRequest{Name: message}
-	res, err := client.SayHelloWorld(context.Background(), req)
+	router := gin.Default()
+	err = register(router, routes)
 	if err != nil {
-		c.JSON(500, gin.H{
-			"message": err.Error(),
-		})
-		return
+		log.Fatalln(err)
 	}
-	c.JSON(200, gin.H{
-		"message": res.GetMessage(),
-	})
-}
\ No newline at end of file
+	router.Run(*address)
+}
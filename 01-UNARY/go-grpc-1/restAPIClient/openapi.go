@@ -0,0 +1,285 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// schema is an OpenAPI schema object, kept as a map so only the keywords
+// that are set get encoded
+type schema map[string]any
+
+// openAPI builds an OpenAPI 3 document from the proto descriptors of routes
+type openAPI struct {
+	schemas map[string]schema
+}
+
+// newOpenAPIDocument returns the OpenAPI 3 document of routes, encoded as JSON
+func newOpenAPIDocument(routes []route) ([]byte, error) {
+	api := &openAPI{schemas: map[string]schema{}}
+
+	paths := map[string]map[string]any{}
+	for _, r := range routes {
+		path, params := openAPIPath(r.path)
+		if paths[path] == nil {
+			paths[path] = map[string]any{}
+		}
+		paths[path][strings.ToLower(r.method)] = api.operation(r, params)
+	}
+
+	document := map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "gRPC REST gateway",
+			"version": "1.0.0",
+			"description": "Routes mapped to unary gRPC calls. Bodies are protobuf messages encoded as JSON. " +
+				"Request headers are passed on as gRPC metadata, response header metadata comes back in " +
+				"Grpc-Metadata-* headers, and a Grpc-Timeout header sets the deadline of the call.",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": api.schemas,
+		},
+	}
+	return json.MarshalIndent(document, "", "  ")
+}
+
+// openAPIPath turns a gin path such as /v1/invoices/:id into /v1/invoices/{id}
+// and returns the names of its parameters
+func openAPIPath(path string) (string, []string) {
+	segments := strings.Split(path, "/")
+	params := []string{}
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			params = append(params, segment[1:])
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+func (api *openAPI) operation(r route, pathParams []string) map[string]any {
+	input := r.rpc.Input()
+	parameters := []any{}
+
+	bound := map[string]bool{}
+	for _, name := range pathParams {
+		bound[name] = true
+		parameter := map[string]any{"name": name, "in": "path", "required": true, "schema": schema{"type": "string"}}
+		if field := fieldByName(input, name); field != nil {
+			parameter["schema"] = api.fieldSchema(field)
+		}
+		parameters = append(parameters, parameter)
+	}
+
+	operation := map[string]any{
+		"operationId": fmt.Sprintf("%s_%s", r.rpc.Name(), strings.ToLower(r.method)),
+		"summary":     string(r.rpc.FullName()),
+		"tags":        []string{string(r.rpc.Parent().FullName())},
+	}
+
+	if r.method == http.MethodGet || r.method == http.MethodDelete {
+		// without a body, the other fields come from the query
+		fields := input.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			field := fields.Get(i)
+			if bound[field.JSONName()] || bound[string(field.Name())] || !queryable(field) {
+				continue
+			}
+			parameter := map[string]any{"name": field.JSONName(), "in": "query", "schema": api.fieldSchema(field)}
+			if field.IsMap() {
+				parameter["style"] = "deepObject"
+				parameter["explode"] = true
+			}
+			parameters = append(parameters, parameter)
+		}
+	} else {
+		operation["requestBody"] = map[string]any{
+			"required": true,
+			"content": map[string]any{
+				"application/json": map[string]any{"schema": api.messageSchema(input)},
+			},
+		}
+	}
+
+	if len(parameters) > 0 {
+		operation["parameters"] = parameters
+	}
+	operation["responses"] = api.responses(r.rpc.Output())
+	return operation
+}
+
+// responses lists the success response and one error response per HTTP
+// status that a gRPC status code maps to
+func (api *openAPI) responses(output protoreflect.MessageDescriptor) map[string]any {
+	responses := map[string]any{
+		"200": map[string]any{
+			"description": "OK",
+			"content": map[string]any{
+				"application/json": map[string]any{"schema": api.messageSchema(output)},
+			},
+		},
+	}
+
+	byStatus := map[int][]string{}
+	for code := codes.Canceled; code <= codes.Unauthenticated; code++ {
+		httpCode := httpStatus(code)
+		byStatus[httpCode] = append(byStatus[httpCode], strings.ToUpper(toSnakeCase(code.String())))
+	}
+
+	statusSchema := api.messageSchema((&spb.Status{}).ProtoReflect().Descriptor())
+	for httpCode, grpcCodes := range byStatus {
+		sort.Strings(grpcCodes)
+		responses[strconv.Itoa(httpCode)] = map[string]any{
+			"description": "gRPC status " + strings.Join(grpcCodes, ", "),
+			"content": map[string]any{
+				"application/json": map[string]any{"schema": statusSchema},
+			},
+		}
+	}
+	return responses
+}
+
+// messageSchema returns a reference to the schema of message, adding the
+// schema and the schemas it refers to on first use
+func (api *openAPI) messageSchema(message protoreflect.MessageDescriptor) schema {
+	if wellKnown, ok := wellKnownSchema(message); ok {
+		return wellKnown
+	}
+
+	name := string(message.FullName())
+	ref := schema{"$ref": "#/components/schemas/" + name}
+	if _, ok := api.schemas[name]; ok {
+		return ref
+	}
+
+	properties := map[string]schema{}
+	object := schema{"type": "object", "properties": properties}
+	// register before recursing, so recursive messages terminate
+	api.schemas[name] = object
+
+	fields := message.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		properties[field.JSONName()] = api.fieldSchema(field)
+	}
+	return ref
+}
+
+func (api *openAPI) fieldSchema(field protoreflect.FieldDescriptor) schema {
+	if field.IsMap() {
+		return schema{"type": "object", "additionalProperties": api.singularSchema(field.MapValue())}
+	}
+	if field.IsList() {
+		return schema{"type": "array", "items": api.singularSchema(field)}
+	}
+	return api.singularSchema(field)
+}
+
+// singularSchema is the schema of one value of field, following the protojson encoding
+func (api *openAPI) singularSchema(field protoreflect.FieldDescriptor) schema {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return schema{"type": "boolean"}
+	case protoreflect.StringKind:
+		return schema{"type": "string"}
+	case protoreflect.BytesKind:
+		return schema{"type": "string", "format": "byte"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return schema{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return schema{"type": "integer", "format": "int64", "minimum": 0}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		// protojson writes 64-bit integers as strings so they survive JavaScript
+		return schema{"type": "string", "format": "int64"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return schema{"type": "string", "format": "uint64"}
+	case protoreflect.FloatKind:
+		return schema{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		return schema{"type": "number", "format": "double"}
+	case protoreflect.EnumKind:
+		return api.enumSchema(field.Enum())
+	default:
+		return api.messageSchema(field.Message())
+	}
+}
+
+func (api *openAPI) enumSchema(enum protoreflect.EnumDescriptor) schema {
+	name := string(enum.FullName())
+	ref := schema{"$ref": "#/components/schemas/" + name}
+	if _, ok := api.schemas[name]; ok {
+		return ref
+	}
+
+	values := []string{}
+	for i := 0; i < enum.Values().Len(); i++ {
+		values = append(values, string(enum.Values().Get(i).Name()))
+	}
+	api.schemas[name] = schema{"type": "string", "enum": values}
+	return ref
+}
+
+// wellKnownSchema returns the schema of the well-known types that protojson
+// encodes in a special form
+func wellKnownSchema(message protoreflect.MessageDescriptor) (schema, bool) {
+	switch message.FullName() {
+	case "google.protobuf.Timestamp":
+		return schema{"type": "string", "format": "date-time"}, true
+	case "google.protobuf.Duration":
+		return schema{"type": "string", "example": "1.5s"}, true
+	case "google.protobuf.FieldMask":
+		return schema{"type": "string", "example": "payerName,lineItems"}, true
+	case "google.protobuf.Struct":
+		return schema{"type": "object", "additionalProperties": true}, true
+	case "google.protobuf.Any":
+		return schema{
+			"type":                 "object",
+			"properties":           map[string]schema{"@type": {"type": "string"}},
+			"additionalProperties": true,
+		}, true
+	}
+	return nil, false
+}
+
+// queryable reports whether setField can set field from a query parameter
+func queryable(field protoreflect.FieldDescriptor) bool {
+	switch {
+	case field.IsMap():
+		return field.MapKey().Kind() == protoreflect.StringKind && field.MapValue().Kind() == protoreflect.StringKind
+	case field.IsList():
+		return false
+	case field.Kind() == protoreflect.MessageKind:
+		return field.Message().FullName() == "google.protobuf.Timestamp"
+	case field.Kind() == protoreflect.BytesKind, field.Kind() == protoreflect.GroupKind:
+		return false
+	}
+	return true
+}
+
+func fieldByName(message protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+	if field := message.Fields().ByJSONName(name); field != nil {
+		return field
+	}
+	return message.Fields().ByName(protoreflect.Name(name))
+}
+
+// toSnakeCase turns InvalidArgument into Invalid_Argument
+func toSnakeCase(name string) string {
+	var builder strings.Builder
+	for i, r := range name {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			builder.WriteByte('_')
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	invoice "grpc-2/invoice"
+	proto "grpc1/helloGrpc"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestOpenAPIDocument(t *testing.T) {
+	gw := &gateway{timeout: time.Second}
+	routes := append(helloWorldRoutes(gw, proto.NewHelloWorldClient(nil)), invoiceRoutes(gw, invoice.NewInvoiceClient(nil))...)
+
+	data, err := newOpenAPIDocument(routes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var document struct {
+		Paths      map[string]map[string]json.RawMessage
+		Components struct {
+			Schemas map[string]map[string]any
+		}
+	}
+	err = json.Unmarshal(data, &document)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range routes {
+		path, _ := openAPIPath(r.path)
+		if _, ok := document.Paths[path][strings.ToLower(r.method)]; !ok {
+			t.Errorf("missing operation %s %s", r.method, path)
+		}
+	}
+
+	var getInvoice struct {
+		Parameters []struct{ Name, In string }
+		Responses  map[string]json.RawMessage
+	}
+	err = json.Unmarshal(document.Paths["/v1/invoices/{id}"]["get"], &getInvoice)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(getInvoice.Parameters) != 1 || getInvoice.Parameters[0].Name != "id" || getInvoice.Parameters[0].In != "path" {
+		t.Errorf("got parameters %+v, expected the id path parameter", getInvoice.Parameters)
+	}
+	for _, code := range []string{"200", "400", "404", "409", "499", "500", "503"} {
+		if _, ok := getInvoice.Responses[code]; !ok {
+			t.Errorf("missing response %s", code)
+		}
+	}
+
+	record := document.Components.Schemas["invoice.InvoiceRecord"]["properties"].(map[string]any)
+	total := record["total"].(map[string]any)
+	if total["type"] != "string" || total["format"] != "int64" {
+		t.Errorf("got total schema %v, expected an int64 string", total)
+	}
+	createdAt := record["createdAt"].(map[string]any)
+	if createdAt["format"] != "date-time" {
+		t.Errorf("got createdAt schema %v, expected a date-time", createdAt)
+	}
+	if _, ok := document.Components.Schemas["google.rpc.Status"]; !ok {
+		t.Error("missing google.rpc.Status schema")
+	}
+}
+
+func TestOpenAPIRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	err := register(router, helloWorldRoutes(&gateway{timeout: time.Second}, proto.NewHelloWorldClient(nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if recorder.Code != http.StatusOK || !json.Valid(recorder.Body.Bytes()) {
+		t.Fatalf("got %d %s, expected a JSON document", recorder.Code, recorder.Body)
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	invoice "grpc-2/invoice"
+	proto "grpc1/helloGrpc"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// route maps an HTTP method and path to a unary RPC. The route table drives
+// both the router and the OpenAPI document.
+type route struct {
+	method  string
+	path    string
+	rpc     protoreflect.MethodDescriptor
+	handler gin.HandlerFunc
+}
+
+// unaryRoute builds the route calling the RPC name of service. It panics
+// when call does not match that RPC, which is a mistake in the route table.
+func unaryRoute[Req protobuf.Message, Res protobuf.Message](gw *gateway, service protoreflect.ServiceDescriptor, name string, method string, path string, call func(context.Context, Req, ...grpc.CallOption) (Res, error)) route {
+	rpc := service.Methods().ByName(protoreflect.Name(name))
+	if rpc == nil {
+		panic(fmt.Sprintf("%s has no rpc %s", service.FullName(), name))
+	}
+
+	var req Req
+	var res Res
+	if rpc.Input() != req.ProtoReflect().Descriptor() || rpc.Output() != res.ProtoReflect().Descriptor() {
+		panic(fmt.Sprintf("the call of route %s %s does not match rpc %s", method, path, rpc.FullName()))
+	}
+
+	return route{
+		method:  method,
+		path:    path,
+		rpc:     rpc,
+		handler: unary(gw, call),
+	}
+}
+
+func helloWorldRoutes(gw *gateway, client proto.HelloWorldClient) []route {
+	service := proto.File_helloworld_proto.Services().ByName("HelloWorld")
+	return []route{
+		unaryRoute(gw, service, "SayHelloWorld", http.MethodGet, "/hello/:name", client.SayHelloWorld),
+		unaryRoute(gw, service, "SayHelloWorld", http.MethodPost, "/v1/hello", client.SayHelloWorld),
+	}
+}
+
+// invoiceRoutes maps the unary Invoice RPCs to REST routes. The streaming
+// RenderInvoice and WatchInvoices have no route.
+func invoiceRoutes(gw *gateway, client invoice.InvoiceClient) []route {
+	service := invoice.File_invoice_proto.Services().ByName("Invoice")
+	return []route{
+		unaryRoute(gw, service, "CreateInvoice", http.MethodPost, "/v1/invoices", client.CreateInvoice),
+		unaryRoute(gw, service, "ListInvoices", http.MethodGet, "/v1/invoices", client.ListInvoices),
+		unaryRoute(gw, service, "GetInvoice", http.MethodGet, "/v1/invoices/:id", client.GetInvoice),
+		unaryRoute(gw, service, "UpdateInvoice", http.MethodPatch, "/v1/invoices/:id", client.UpdateInvoice),
+		unaryRoute(gw, service, "DeleteInvoice", http.MethodDelete, "/v1/invoices/:id", client.DeleteInvoice),
+		unaryRoute(gw, service, "PayInvoice", http.MethodPost, "/v1/invoices/:id/pay", client.PayInvoice),
+		unaryRoute(gw, service, "ChangeInvoiceStatus", http.MethodPost, "/v1/invoices/:id/status", client.ChangeInvoiceStatus),
+	}
+}
+
+// register adds routes to router, along with the OpenAPI document describing them
+func register(router gin.IRouter, routes []route) error {
+	for _, r := range routes {
+		router.Handle(r.method, r.path, r.handler)
+	}
+
+	document, err := newOpenAPIDocument(routes)
+	if err != nil {
+		return fmt.Errorf("cannot build openapi document: %w", err)
+	}
+	router.GET("/openapi.json", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json", document)
+	})
+	return nil
+}
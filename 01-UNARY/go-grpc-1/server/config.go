@@ -0,0 +1,124 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+)
+
+// envPrefix is prepended to a flag name to get the environment variable that
+// sets it, so -max-message-size is also HELLO_MAX_MESSAGE_SIZE
+const envPrefix = "HELLO_"
+
+// config is the configuration of the server
+type config struct {
+	Address string
+	// TLSCert and TLSKey are PEM files; the server uses plain TCP when both are empty
+	TLSCert string
+	TLSKey  string
+	// MaxMessageSize is the largest message in bytes the server receives or sends
+	MaxMessageSize int
+
+	// KeepaliveTime is how long a connection may be idle before the server
+	// pings the client, and KeepaliveTimeout how long it waits for the ack
+	KeepaliveTime    time.Duration
+	KeepaliveTimeout time.Duration
+	// KeepaliveMinTime is the shortest interval at which clients may ping;
+	// clients pinging more often are disconnected
+	KeepaliveMinTime time.Duration
+	// MaxConnectionIdle and MaxConnectionAge close idle and long-lived
+	// connections, zero keeps them open
+	MaxConnectionIdle time.Duration
+	MaxConnectionAge  time.Duration
+
+	// DrainTimeout is how long in-flight calls may run after a shutdown signal
+	// before the server closes them
+	DrainTimeout time.Duration
+}
+
+// loadConfig reads the configuration from args, then from the environment
+// for the flags args does not set, then from the defaults
+func loadConfig(args []string, lookupEnv func(key string) (string, bool)) (config, error) {
+	cfg := config{}
+
+	flags := flag.NewFlagSet("server", flag.ContinueOnError)
+	flags.StringVar(&cfg.Address, "address", ":9000", "address to listen on")
+	flags.StringVar(&cfg.TLSCert, "tls-cert", "", "TLS certificate file")
+	flags.StringVar(&cfg.TLSKey, "tls-key", "", "TLS private key file")
+	flags.IntVar(&cfg.MaxMessageSize, "max-message-size", 4<<20, "largest message in bytes received or sent")
+	flags.DurationVar(&cfg.KeepaliveTime, "keepalive-time", 2*time.Hour, "idle time before pinging a client")
+	flags.DurationVar(&cfg.KeepaliveTimeout, "keepalive-timeout", 20*time.Second, "time to wait for a ping ack before closing the connection")
+	flags.DurationVar(&cfg.KeepaliveMinTime, "keepalive-min-time", 5*time.Minute, "shortest interval at which clients may ping")
+	flags.DurationVar(&cfg.MaxConnectionIdle, "max-connection-idle", 0, "close connections idle for this long, 0 never does")
+	flags.DurationVar(&cfg.MaxConnectionAge, "max-connection-age", 0, "close connections open for this long, 0 never does")
+	flags.DurationVar(&cfg.DrainTimeout, "drain-timeout", 30*time.Second, "time in-flight calls may run after SIGINT or SIGTERM")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return config{}, err
+	}
+
+	set := map[string]bool{}
+	flags.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	flags.VisitAll(func(f *flag.Flag) {
+		if err != nil || set[f.Name] {
+			return
+		}
+		key := envPrefix + strings.ToUpper(strings.ReplaceAll(f.Name, "-", "_"))
+		if value, ok := lookupEnv(key); ok {
+			if setErr := f.Value.Set(value); setErr != nil {
+				err = fmt.Errorf("invalid %s: %w", key, setErr)
+			}
+		}
+	})
+	if err != nil {
+		return config{}, err
+	}
+
+	return cfg, cfg.validate()
+}
+
+func (cfg config) validate() error {
+	if (cfg.TLSCert == "") != (cfg.TLSKey == "") {
+		return errors.New("tls-cert and tls-key must be set together")
+	}
+	if cfg.MaxMessageSize <= 0 {
+		return fmt.Errorf("max-message-size must be positive, got %d", cfg.MaxMessageSize)
+	}
+	if cfg.DrainTimeout < 0 {
+		return fmt.Errorf("drain-timeout must not be negative, got %s", cfg.DrainTimeout)
+	}
+	return nil
+}
+
+// serverOptions returns the gRPC server options of cfg
+func (cfg config) serverOptions() ([]grpc.ServerOption, error) {
+	options := []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(cfg.MaxMessageSize),
+		grpc.MaxSendMsgSize(cfg.MaxMessageSize),
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:              cfg.KeepaliveTime,
+			Timeout:           cfg.KeepaliveTimeout,
+			MaxConnectionIdle: cfg.MaxConnectionIdle,
+			MaxConnectionAge:  cfg.MaxConnectionAge,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime: cfg.KeepaliveMinTime,
+		}),
+	}
+
+	if cfg.TLSCert != "" {
+		creds, err := credentials.NewServerTLSFromFile(cfg.TLSCert, cfg.TLSKey)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load TLS credentials: %w", err)
+		}
+		options = append(options, grpc.Creds(creds))
+	}
+	return options, nil
+}
@@ -6,27 +6,81 @@ import (
 	proto "grpc1/helloGrpc"
 	"log"
 	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
 
-type server struct{
+type server struct {
 	proto.UnimplementedHelloWorldServer
 }
 
 func main() {
-	listener, tcpErr := net.Listen("tcp", ":9000")
-	if tcpErr != nil {
-		log.Fatalln(tcpErr)
+	cfg, err := loadConfig(os.Args[1:], os.LookupEnv)
+	if err != nil {
+		log.Fatalf("cannot load config: %v", err)
 	}
-	serv := grpc.NewServer()
+
+	options, err := cfg.serverOptions()
+	if err != nil {
+		log.Fatalln(err)
+	}
+	serv := grpc.NewServer(options...)
 	proto.RegisterHelloWorldServer(serv, &server{})
 	reflection.Register(serv)
 
-	if err := serv.Serve(listener); err != nil {
+	listener, err := net.Listen("tcp", cfg.Address)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Printf("listening on %s, tls %t", listener.Addr(), cfg.TLSCert != "")
+	err = serve(ctx, serv, listener, cfg.DrainTimeout)
+	if err != nil {
 		log.Fatalln(err)
 	}
+	log.Println("server stopped")
+}
+
+// serve serves on listener until ctx is done, then stops serv gracefully.
+// Calls still running after drainTimeout are cancelled.
+func serve(ctx context.Context, serv *grpc.Server, listener net.Listener, drainTimeout time.Duration) error {
+	served := make(chan error, 1)
+	go func() {
+		served <- serv.Serve(listener)
+	}()
+
+	select {
+	case err := <-served:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Printf("shutting down, draining calls for up to %s", drainTimeout)
+	stopped := make(chan struct{})
+	go func() {
+		serv.GracefulStop()
+		close(stopped)
+	}()
+
+	timer := time.NewTimer(drainTimeout)
+	defer timer.Stop()
+	select {
+	case <-stopped:
+	case <-timer.C:
+		log.Println("drain timeout reached, closing remaining calls")
+		serv.Stop()
+		<-stopped
+	}
+
+	return <-served
 }
 
 func (s *server) SayHelloWorld(ctx context.Context, req *proto.HelloWorldRequest) (*proto.HelloWorldResponse, error) {
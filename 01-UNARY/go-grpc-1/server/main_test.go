@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	proto "grpc1/helloGrpc"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestLoadConfig(t *testing.T) {
+	env := map[string]string{
+		"HELLO_ADDRESS":          ":9100",
+		"HELLO_MAX_MESSAGE_SIZE": "1024",
+		"HELLO_DRAIN_TIMEOUT":    "5s",
+	}
+	lookupEnv := func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+
+	cfg, err := loadConfig([]string{"-address", ":9200", "-keepalive-time", "1m"}, lookupEnv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// flags win over the environment, which wins over the defaults
+	if cfg.Address != ":9200" {
+		t.Errorf("got address %q, expected the flag", cfg.Address)
+	}
+	if cfg.MaxMessageSize != 1024 || cfg.DrainTimeout != 5*time.Second {
+		t.Errorf("got max message size %d and drain timeout %s, expected the environment", cfg.MaxMessageSize, cfg.DrainTimeout)
+	}
+	if cfg.KeepaliveTime != time.Minute || cfg.KeepaliveTimeout != 20*time.Second {
+		t.Errorf("got keepalive %s/%s", cfg.KeepaliveTime, cfg.KeepaliveTimeout)
+	}
+
+	invalid := []struct {
+		args []string
+		env  map[string]string
+	}{
+		{args: []string{"-tls-cert", "cert.pem"}},
+		{args: []string{"-max-message-size", "0"}},
+		{env: map[string]string{"HELLO_DRAIN_TIMEOUT": "soon"}},
+	}
+	for _, tc := range invalid {
+		env = tc.env
+		_, err := loadConfig(tc.args, lookupEnv)
+		if err == nil {
+			t.Errorf("loadConfig(%v) with env %v succeeded, expected an error", tc.args, tc.env)
+		}
+	}
+}
+
+// slowServer answers once ctx is done, so only a hard stop ends its calls
+type slowServer struct {
+	proto.UnimplementedHelloWorldServer
+	started chan struct{}
+}
+
+func (s *slowServer) SayHelloWorld(ctx context.Context, req *proto.HelloWorldRequest) (*proto.HelloWorldResponse, error) {
+	close(s.started)
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestServeDrainTimeout(t *testing.T) {
+	listener := bufconn.Listen(1 << 20)
+	serv := grpc.NewServer()
+	slow := &slowServer{started: make(chan struct{})}
+	proto.RegisterHelloWorldServer(serv, slow)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- serve(ctx, serv, listener, 100*time.Millisecond)
+	}()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	called := make(chan error, 1)
+	go func() {
+		_, err := proto.NewHelloWorldClient(conn).SayHelloWorld(context.Background(), &proto.HelloWorldRequest{Name: "Mark"})
+		called <- err
+	}()
+
+	<-slow.started
+	cancel()
+
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Fatalf("serve returned %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("serve did not return after the drain timeout")
+	}
+
+	err = <-called
+	if code := status.Code(err); code != codes.Unavailable && code != codes.Canceled {
+		t.Fatalf("got %v, expected the call to be cut off", err)
+	}
+}
@@ -0,0 +1,191 @@
+package billing_test
+
+import (
+	"grpc-2/billing"
+	proto "grpc-2/invoice"
+	"grpc-2/money"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransition(t *testing.T) {
+	t.Parallel()
+
+	invoice := &proto.InvoiceRecord{Status: proto.InvoiceStatus_INVOICE_STATUS_DRAFT}
+
+	err := billing.Transition(invoice, proto.InvoiceStatus_INVOICE_STATUS_PAID)
+	require.ErrorIs(t, err, billing.ErrInvalidTransition)
+	require.Equal(t, proto.InvoiceStatus_INVOICE_STATUS_DRAFT, invoice.Status)
+
+	require.NoError(t, billing.Transition(invoice, proto.InvoiceStatus_INVOICE_STATUS_ISSUED))
+	require.NoError(t, billing.Transition(invoice, proto.InvoiceStatus_INVOICE_STATUS_VOIDED))
+
+	// voided is final
+	for status := range proto.InvoiceStatus_name {
+		require.False(t, billing.CanTransition(invoice.Status, proto.InvoiceStatus(status)))
+	}
+}
+
+func TestPay(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	invoice := &proto.InvoiceRecord{
+		Total:  10000,
+		Status: proto.InvoiceStatus_INVOICE_STATUS_DRAFT,
+	}
+
+	_, _, err := billing.Pay(invoice, 1000, "key-1", now)
+	require.ErrorIs(t, err, billing.ErrInvalidTransition)
+
+	invoice.Status = proto.InvoiceStatus_INVOICE_STATUS_ISSUED
+
+	_, _, err = billing.Pay(invoice, 0, "key-1", now)
+	require.ErrorIs(t, err, money.ErrInvalidAmount)
+	_, _, err = billing.Pay(invoice, 10001, "key-1", now)
+	require.ErrorIs(t, err, billing.ErrOverpayment)
+
+	payment, replayed, err := billing.Pay(invoice, 3000, "key-1", now)
+	require.NoError(t, err)
+	require.False(t, replayed)
+	require.Equal(t, int64(3000), payment.Amount)
+	require.Equal(t, proto.InvoiceStatus_INVOICE_STATUS_PARTIALLY_PAID, invoice.Status)
+	require.Equal(t, int64(3000), invoice.AmountPaid)
+
+	// a retry with the same key returns the original payment
+	again, replayed, err := billing.Pay(invoice, 3000, "key-1", now)
+	require.NoError(t, err)
+	require.True(t, replayed)
+	require.Equal(t, payment.Id, again.Id)
+	require.Len(t, invoice.Payments, 1)
+	require.Equal(t, int64(3000), invoice.AmountPaid)
+
+	_, _, err = billing.Pay(invoice, 4000, "key-1", now)
+	require.ErrorIs(t, err, billing.ErrIdempotencyKeyReused)
+
+	_, _, err = billing.Pay(invoice, 7001, "key-2", now)
+	require.ErrorIs(t, err, billing.ErrOverpayment)
+
+	payment, _, err = billing.Pay(invoice, 7000, "key-2", now)
+	require.NoError(t, err)
+	require.Equal(t, proto.InvoiceStatus_INVOICE_STATUS_PAID, payment.StatusAfter)
+	require.Equal(t, int64(10000), payment.AmountPaidAfter)
+	require.Equal(t, proto.InvoiceStatus_INVOICE_STATUS_PAID, invoice.Status)
+
+	// the first retry still replays after the invoice was paid in full
+	again, replayed, err = billing.Pay(invoice, 3000, "key-1", now)
+	require.NoError(t, err)
+	require.True(t, replayed)
+	require.Equal(t, proto.InvoiceStatus_INVOICE_STATUS_PARTIALLY_PAID, again.StatusAfter)
+
+	_, _, err = billing.Pay(invoice, 100, "key-3", now)
+	require.ErrorIs(t, err, billing.ErrInvalidTransition)
+}
+
+func TestComputeTotals(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		invoice *proto.InvoiceRecord
+		err     error
+		// subtotal, tax and total expected on success
+		totals [3]int64
+	}{
+		{
+			name:    "amount_without_line_items",
+			invoice: &proto.InvoiceRecord{Currency: "EUR", Total: 1230},
+			totals:  [3]int64{1230, 0, 1230},
+		},
+		{
+			name:    "missing_amount",
+			invoice: &proto.InvoiceRecord{Currency: "EUR"},
+			err:     money.ErrInvalidAmount,
+		},
+		{
+			name:    "unknown_currency",
+			invoice: &proto.InvoiceRecord{Currency: "XYZ", Total: 1230},
+			err:     money.ErrUnknownCurrency,
+		},
+		{
+			// 20% of 1005 is 201 and 20% of 1015 is 203: each line is rounded half up
+			name: "line_items",
+			invoice: &proto.InvoiceRecord{
+				Currency: "EUR",
+				LineItems: []*proto.LineItem{
+					{Quantity: 1, UnitPrice: 1005, TaxRate: 2000},
+					{Quantity: 5, UnitPrice: 203, TaxRate: 2000},
+					{Quantity: 2, UnitPrice: 500},
+				},
+			},
+			totals: [3]int64{3020, 404, 3424},
+		},
+		{
+			name: "expected_total",
+			invoice: &proto.InvoiceRecord{
+				Currency:  "JPY",
+				Total:     1100,
+				LineItems: []*proto.LineItem{{Quantity: 1, UnitPrice: 1000, TaxRate: 1000, Total: 1100}},
+			},
+			totals: [3]int64{1000, 100, 1100},
+		},
+		{
+			name: "total_mismatch",
+			invoice: &proto.InvoiceRecord{
+				Currency:  "EUR",
+				Total:     1000,
+				LineItems: []*proto.LineItem{{Quantity: 1, UnitPrice: 1000, TaxRate: 1000}},
+			},
+			err: billing.ErrTotalMismatch,
+		},
+		{
+			name: "line_item_total_mismatch",
+			invoice: &proto.InvoiceRecord{
+				Currency:  "EUR",
+				LineItems: []*proto.LineItem{{Quantity: 1, UnitPrice: 1000, Tax: 1}},
+			},
+			err: billing.ErrTotalMismatch,
+		},
+		{
+			name: "invalid_quantity",
+			invoice: &proto.InvoiceRecord{
+				Currency:  "EUR",
+				LineItems: []*proto.LineItem{{Quantity: 0, UnitPrice: 1000}},
+			},
+			err: billing.ErrInvalidLineItem,
+		},
+		{
+			name: "invalid_tax_rate",
+			invoice: &proto.InvoiceRecord{
+				Currency:  "EUR",
+				LineItems: []*proto.LineItem{{Quantity: 1, UnitPrice: 1000, TaxRate: 10001}},
+			},
+			err: billing.ErrInvalidLineItem,
+		},
+		{
+			name: "overflow",
+			invoice: &proto.InvoiceRecord{
+				Currency:  "EUR",
+				LineItems: []*proto.LineItem{{Quantity: 1 << 32, UnitPrice: 1 << 32}},
+			},
+			err: money.ErrInvalidAmount,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := billing.ComputeTotals(tc.invoice)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.totals, [3]int64{tc.invoice.Subtotal, tc.invoice.Tax, tc.invoice.Total})
+		})
+	}
+}
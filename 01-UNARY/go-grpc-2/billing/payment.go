@@ -0,0 +1,80 @@
+package billing
+
+import (
+	"errors"
+	"fmt"
+	proto "grpc-2/invoice"
+	"grpc-2/money"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	// ErrOverpayment is returned for a payment larger than the outstanding balance
+	ErrOverpayment = errors.New("payment exceeds the outstanding balance")
+	// ErrIdempotencyKeyReused is returned when a key is sent again with a different payment
+	ErrIdempotencyKeyReused = errors.New("idempotency key was used for a different payment")
+)
+
+// FindPayment returns the payment of invoice made with idempotencyKey, or nil
+func FindPayment(invoice *proto.InvoiceRecord, idempotencyKey string) *proto.Payment {
+	if idempotencyKey == "" {
+		return nil
+	}
+	for _, payment := range invoice.Payments {
+		if payment.IdempotencyKey == idempotencyKey {
+			return payment
+		}
+	}
+	return nil
+}
+
+// Pay records a payment of amount minor units against invoice and moves it to
+// partially paid or paid. When a payment with the same idempotency key is already
+// recorded, that payment is returned and the invoice is left unchanged.
+func Pay(invoice *proto.InvoiceRecord, amount int64, idempotencyKey string, now time.Time) (*proto.Payment, bool, error) {
+	if amount <= 0 {
+		return nil, false, fmt.Errorf("%w: payment must be positive, got %d", money.ErrInvalidAmount, amount)
+	}
+
+	if payment := FindPayment(invoice, idempotencyKey); payment != nil {
+		if payment.Amount != amount {
+			return nil, false, ErrIdempotencyKeyReused
+		}
+		return payment, true, nil
+	}
+
+	if !CanTransition(invoice.Status, proto.InvoiceStatus_INVOICE_STATUS_PARTIALLY_PAID) &&
+		!CanTransition(invoice.Status, proto.InvoiceStatus_INVOICE_STATUS_PAID) {
+		return nil, false, fmt.Errorf("%w: cannot pay an invoice that is %s", ErrInvalidTransition, invoice.Status)
+	}
+
+	outstanding := invoice.Total - invoice.AmountPaid
+	if amount > outstanding {
+		return nil, false, fmt.Errorf("%w: %d outstanding", ErrOverpayment, outstanding)
+	}
+
+	status := proto.InvoiceStatus_INVOICE_STATUS_PARTIALLY_PAID
+	if amount == outstanding {
+		status = proto.InvoiceStatus_INVOICE_STATUS_PAID
+	}
+	err := Transition(invoice, status)
+	if err != nil {
+		return nil, false, err
+	}
+
+	invoice.AmountPaid += amount
+	payment := &proto.Payment{
+		Id:              uuid.NewString(),
+		Amount:          amount,
+		IdempotencyKey:  idempotencyKey,
+		CreatedAt:       timestamppb.New(now),
+		StatusAfter:     invoice.Status,
+		AmountPaidAfter: invoice.AmountPaid,
+	}
+	invoice.Payments = append(invoice.Payments, payment)
+
+	return payment, false, nil
+}
@@ -0,0 +1,57 @@
+package billing
+
+import (
+	"errors"
+	"fmt"
+	proto "grpc-2/invoice"
+)
+
+// ErrInvalidTransition is returned for a status change the lifecycle does not allow
+var ErrInvalidTransition = errors.New("invalid invoice status transition")
+
+// transitions lists the statuses each status may move to
+var transitions = map[proto.InvoiceStatus][]proto.InvoiceStatus{
+	proto.InvoiceStatus_INVOICE_STATUS_DRAFT: {
+		proto.InvoiceStatus_INVOICE_STATUS_ISSUED,
+		proto.InvoiceStatus_INVOICE_STATUS_VOIDED,
+	},
+	proto.InvoiceStatus_INVOICE_STATUS_ISSUED: {
+		proto.InvoiceStatus_INVOICE_STATUS_PARTIALLY_PAID,
+		proto.InvoiceStatus_INVOICE_STATUS_PAID,
+		proto.InvoiceStatus_INVOICE_STATUS_VOIDED,
+	},
+	proto.InvoiceStatus_INVOICE_STATUS_PARTIALLY_PAID: {
+		proto.InvoiceStatus_INVOICE_STATUS_PARTIALLY_PAID,
+		proto.InvoiceStatus_INVOICE_STATUS_PAID,
+		proto.InvoiceStatus_INVOICE_STATUS_REFUNDED,
+	},
+	proto.InvoiceStatus_INVOICE_STATUS_PAID: {
+		proto.InvoiceStatus_INVOICE_STATUS_REFUNDED,
+	},
+}
+
+// CanTransition reports whether an invoice may move from one status to another
+func CanTransition(from proto.InvoiceStatus, to proto.InvoiceStatus) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Transition moves invoice to status, or returns ErrInvalidTransition
+func Transition(invoice *proto.InvoiceRecord, status proto.InvoiceStatus) error {
+	if !CanTransition(invoice.Status, status) {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidTransition, invoice.Status, status)
+	}
+
+	invoice.Status = status
+	return nil
+}
+
+// IsPaymentStatus reports whether status can only be reached by paying
+func IsPaymentStatus(status proto.InvoiceStatus) bool {
+	return status == proto.InvoiceStatus_INVOICE_STATUS_PARTIALLY_PAID ||
+		status == proto.InvoiceStatus_INVOICE_STATUS_PAID
+}
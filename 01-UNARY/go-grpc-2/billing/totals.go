@@ -0,0 +1,126 @@
+package billing
+
+import (
+	"errors"
+	"fmt"
+	proto "grpc-2/invoice"
+	"grpc-2/money"
+)
+
+var (
+	// ErrInvalidLineItem is returned for a line item with a bad quantity, price or tax rate
+	ErrInvalidLineItem = errors.New("invalid line item")
+	// ErrTotalMismatch is returned when a total sent by the client differs from the computed one
+	ErrTotalMismatch = errors.New("total does not match the line items")
+)
+
+// ComputeTotals checks the currency of invoice and computes the totals of its
+// line items and of the invoice itself. Without line items, the invoice total
+// must already be set; with line items, a total that is already set must
+// match the computed one.
+func ComputeTotals(invoice *proto.InvoiceRecord) error {
+	_, err := money.Lookup(invoice.Currency)
+	if err != nil {
+		return err
+	}
+
+	if len(invoice.LineItems) == 0 {
+		if invoice.Total <= 0 {
+			return fmt.Errorf("%w: an invoice without line items needs a positive amount", money.ErrInvalidAmount)
+		}
+		invoice.Subtotal = invoice.Total
+		invoice.Tax = 0
+		return nil
+	}
+
+	var subtotal, tax, total int64
+	for i, item := range invoice.LineItems {
+		err := computeLineItem(item)
+		if err != nil {
+			return fmt.Errorf("lineItems[%d]: %w", i, err)
+		}
+
+		subtotal, err = money.Add(subtotal, item.Subtotal)
+		if err != nil {
+			return err
+		}
+		tax, err = money.Add(tax, item.Tax)
+		if err != nil {
+			return err
+		}
+		total, err = money.Add(total, item.Total)
+		if err != nil {
+			return err
+		}
+	}
+
+	if invoice.Total != 0 && invoice.Total != total {
+		return fmt.Errorf("%w: got %d, line items add up to %d", ErrTotalMismatch, invoice.Total, total)
+	}
+	invoice.Subtotal = subtotal
+	invoice.Tax = tax
+	invoice.Total = total
+	return nil
+}
+
+// LineItemViolation is a rule broken by a line item. Field is the name of the
+// offending field of the line item.
+type LineItemViolation struct {
+	Field       string
+	Description string
+}
+
+// CheckLineItem returns every rule item breaks: the quantity must be
+// positive, the unit price must not be negative and the tax rate must be
+// between 0 and 100%.
+func CheckLineItem(item *proto.LineItem) []LineItemViolation {
+	violations := []LineItemViolation{}
+	if item.GetQuantity() <= 0 {
+		violations = append(violations, LineItemViolation{
+			Field:       "quantity",
+			Description: fmt.Sprintf("must be positive, got %d", item.GetQuantity()),
+		})
+	}
+	if item.GetUnitPrice() < 0 {
+		violations = append(violations, LineItemViolation{
+			Field:       "unitPrice",
+			Description: fmt.Sprintf("must not be negative, got %d", item.GetUnitPrice()),
+		})
+	}
+	if item.GetTaxRate() < 0 || item.GetTaxRate() > money.BasisPoints {
+		violations = append(violations, LineItemViolation{
+			Field:       "taxRate",
+			Description: fmt.Sprintf("must be between 0 and %d basis points, got %d", money.BasisPoints, item.GetTaxRate()),
+		})
+	}
+	return violations
+}
+
+func computeLineItem(item *proto.LineItem) error {
+	if violations := CheckLineItem(item); len(violations) > 0 {
+		return fmt.Errorf("%w: %s %s", ErrInvalidLineItem, violations[0].Field, violations[0].Description)
+	}
+
+	subtotal, err := money.Multiply(item.Quantity, item.UnitPrice)
+	if err != nil {
+		return err
+	}
+	tax, err := money.ApplyRate(subtotal, int64(item.TaxRate))
+	if err != nil {
+		return err
+	}
+	total, err := money.Add(subtotal, tax)
+	if err != nil {
+		return err
+	}
+
+	if (item.Subtotal != 0 && item.Subtotal != subtotal) ||
+		(item.Tax != 0 && item.Tax != tax) ||
+		(item.Total != 0 && item.Total != total) {
+		return fmt.Errorf("%w: expected subtotal %d, tax %d and total %d", ErrTotalMismatch, subtotal, tax, total)
+	}
+	item.Subtotal = subtotal
+	item.Tax = tax
+	item.Total = total
+	return nil
+}
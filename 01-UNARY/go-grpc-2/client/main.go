@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	proto "grpc-2/invoice"
+	"io"
 	"log"
 
 	// "log"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 )
 
 func main() {
@@ -21,42 +23,63 @@ func main() {
 	}
 
 	client := proto.NewInvoiceClient(conn)
-	req := &proto.InvoiceRequest{
-		Id: "test",
-	}
 
-	log.Println("requesting invoice...")
-	res, err := client.GetInvoice(context.Background(), req)
+	log.Println("watching invoices...")
+	watch, err := client.WatchInvoices(context.Background(), &proto.WatchInvoicesRequest{})
 	if err != nil {
 		panic(err)
 	}
-
-	log.Println("got invoice...")
-	fmt.Println(res)
-
+	go func() {
+		for {
+			event, err := watch.Recv()
+			if err != nil {
+				log.Printf("watch ended: %v", err)
+				return
+			}
+			log.Printf("event %d: %s %s", event.Sequence, event.Type, event.Invoice.Id)
+		}
+	}()
 
 	log.Println("creating invoice...")
 	inv, err := client.CreateInvoice(context.Background(), &proto.CreateInvoiceRequest{
-		Id: "test",
 		PayerId: "test",
 		PayerName: "test",
-		PayerEmail: "test",
-		Amount: "test",
-		Currency: "test",
+		PayerEmail: "test@example.com",
+		Currency: "EUR",
 		Description: "test",
-		Metadata: "test",
+		Metadata: map[string]string{"project": "test"},
+		LineItems: []*proto.LineItem{
+			{Description: "consulting", Quantity: 3, UnitPrice: 2500, TaxRate: 2000},
+			{Description: "travel", Quantity: 1, UnitPrice: 1999},
+		},
 	})
+	if err != nil {
+		panic(err)
+	}
 
 	fmt.Println("Generated Invoice :\n",inv)
-	
+
+
+	req := &proto.InvoiceRequest{
+		Id: inv.Id,
+	}
+
+	log.Println("requesting invoice...")
+	res, err := client.GetInvoice(context.Background(), req)
+	if err != nil {
+		panic(err)
+	}
+
+	log.Println("got invoice...")
+	fmt.Println(res)
 
 
 	log.Println("updating invoice...")
 	updInv := &proto.UpdateInvoiceRequest{
-		Id: "test",
+		Id: inv.Id,
 		PayerId: "test",
 		PayerName: "test",
-		PayerEmail: "test",
+		PayerEmail: "test@example.com",
 	}
 
 	updRes, err := client.UpdateInvoice(context.Background(), updInv)
@@ -66,9 +89,58 @@ func main() {
 	fmt.Println("Updated Invoice :\n",updRes)
 
 
+	log.Println("issuing invoice...")
+	_, err = client.ChangeInvoiceStatus(context.Background(), &proto.ChangeInvoiceStatusRequest{
+		Id: inv.Id,
+		Status: proto.InvoiceStatus_INVOICE_STATUS_ISSUED,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	log.Println("paying invoice twice with the same idempotency key...")
+	payCtx := metadata.AppendToOutgoingContext(context.Background(), "idempotency-key", inv.Id+"-1")
+	for i := 0; i < 2; i++ {
+		var header metadata.MD
+		payRes, err := client.PayInvoice(payCtx, &proto.PayInvoiceRequest{
+			Id: inv.Id,
+			Amount: 4000,
+		}, grpc.Header(&header))
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println("Payment :\n",payRes, "replayed:", header.Get("idempotent-replayed"))
+	}
+
+
+	log.Println("rendering invoice as pdf...")
+	stream, err := client.RenderInvoice(context.Background(), &proto.RenderInvoiceRequest{
+		Id: inv.Id,
+		Format: proto.RenderFormat_RENDER_FORMAT_PDF,
+	})
+	if err != nil {
+		panic(err)
+	}
+	document := []byte{}
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			panic(err)
+		}
+		if info := res.GetInfo(); info != nil {
+			fmt.Println("Rendered Invoice :\n",info)
+		}
+		document = append(document, res.GetChunkData()...)
+	}
+	log.Printf("received %d bytes", len(document))
+
+
 	log.Println("deleting invoice...")
 	delRes, err := client.DeleteInvoice(context.Background(), &proto.DeleteInvoiceRequest{
-		Id: "test",
+		Id: inv.Id,
 	})
 	if err != nil {
 		panic(err)
@@ -0,0 +1,218 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	proto "grpc-2/invoice"
+	"sync"
+	"time"
+
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// DefaultHistorySize is the number of past events kept for resuming subscribers
+	DefaultHistorySize = 1024
+	// DefaultBufferSize is the number of events a subscriber may fall behind
+	DefaultBufferSize = 256
+)
+
+var (
+	// ErrSequenceOutOfRange is returned when resuming from a sequence that is
+	// no longer retained, or was never published
+	ErrSequenceOutOfRange = errors.New("sequence is out of range")
+	// ErrSubscriberTooSlow is returned to a subscriber whose buffer overflowed
+	ErrSubscriberTooSlow = errors.New("subscriber fell behind")
+)
+
+// Broker sequences invoice events and fans them out to subscribers. Writers
+// never wait for subscribers: a subscriber whose buffer is full is dropped,
+// and can resume from its last sequence while the event is still retained.
+type Broker struct {
+	mutex        sync.Mutex
+	sequence     uint64
+	history      []*proto.InvoiceEvent
+	historySize  int
+	bufferSize   int
+	subscribers  map[*Subscription]struct{}
+	invoiceLocks map[string]*invoiceLock
+}
+
+// invoiceLock serialises the writes to one invoice, users counts the writers
+// holding or waiting for it
+type invoiceLock struct {
+	mutex sync.Mutex
+	users int
+}
+
+// NewBroker creates a broker that retains historySize events for resuming and
+// lets each subscriber fall up to bufferSize events behind
+func NewBroker(historySize int, bufferSize int) *Broker {
+	return &Broker{
+		historySize:  historySize,
+		bufferSize:   bufferSize,
+		subscribers:  make(map[*Subscription]struct{}),
+		invoiceLocks: make(map[string]*invoiceLock),
+	}
+}
+
+// Commit runs write on the invoice with the given id and publishes an event
+// of type eventType with the invoice it returns. Writes to the same invoice
+// run one at a time, so its events follow the order in which the writes
+// commit, while writes to different invoices run concurrently. No event is
+// published when write fails or returns a nil invoice.
+func (broker *Broker) Commit(eventType proto.InvoiceEventType, id string, write func() (*proto.InvoiceRecord, error)) error {
+	unlock := broker.lockInvoice(id)
+	defer unlock()
+
+	invoice, err := write()
+	if err != nil || invoice == nil {
+		return err
+	}
+
+	broker.publish(eventType, protobuf.Clone(invoice).(*proto.InvoiceRecord))
+	return nil
+}
+
+// lockInvoice waits for the writes to invoice id to finish, and returns the
+// function that lets the next one run
+func (broker *Broker) lockInvoice(id string) func() {
+	broker.mutex.Lock()
+	lock, ok := broker.invoiceLocks[id]
+	if !ok {
+		lock = &invoiceLock{}
+		broker.invoiceLocks[id] = lock
+	}
+	lock.users++
+	broker.mutex.Unlock()
+
+	lock.mutex.Lock()
+	return func() {
+		lock.mutex.Unlock()
+
+		broker.mutex.Lock()
+		defer broker.mutex.Unlock()
+		lock.users--
+		if lock.users == 0 {
+			delete(broker.invoiceLocks, id)
+		}
+	}
+}
+
+// publish assigns the next sequence number to an event and sends it to the subscribers
+func (broker *Broker) publish(eventType proto.InvoiceEventType, invoice *proto.InvoiceRecord) {
+	broker.mutex.Lock()
+	defer broker.mutex.Unlock()
+
+	broker.sequence++
+	event := &proto.InvoiceEvent{
+		Sequence:   broker.sequence,
+		Type:       eventType,
+		Invoice:    invoice,
+		OccurredAt: timestamppb.New(time.Now()),
+	}
+
+	broker.history = append(broker.history, event)
+	if len(broker.history) > broker.historySize {
+		broker.history = broker.history[len(broker.history)-broker.historySize:]
+	}
+
+	for subscription := range broker.subscribers {
+		select {
+		case subscription.events <- event:
+		default:
+			// the subscriber still receives what is buffered, up to the previous event
+			subscription.err = fmt.Errorf("%w, resume after sequence %d", ErrSubscriberTooSlow, event.Sequence-1)
+			broker.unsubscribe(subscription)
+		}
+	}
+}
+
+// Subscribe streams the events published after sequence afterSequence, or
+// only the new ones when afterSequence is 0
+func (broker *Broker) Subscribe(afterSequence uint64) (*Subscription, error) {
+	broker.mutex.Lock()
+	defer broker.mutex.Unlock()
+
+	subscription := &Subscription{
+		broker: broker,
+		events: make(chan *proto.InvoiceEvent, broker.bufferSize),
+		done:   make(chan struct{}),
+	}
+
+	if afterSequence != 0 {
+		oldest := broker.sequence + 1
+		if len(broker.history) > 0 {
+			oldest = broker.history[0].Sequence
+		}
+		if afterSequence > broker.sequence || afterSequence+1 < oldest {
+			return nil, fmt.Errorf("%w: %d is not between %d and %d", ErrSequenceOutOfRange, afterSequence, oldest-1, broker.sequence)
+		}
+
+		for _, event := range broker.history {
+			if event.Sequence > afterSequence {
+				subscription.backlog = append(subscription.backlog, event)
+			}
+		}
+	}
+
+	broker.subscribers[subscription] = struct{}{}
+	return subscription, nil
+}
+
+// unsubscribe must be called with the mutex held
+func (broker *Broker) unsubscribe(subscription *Subscription) {
+	if _, ok := broker.subscribers[subscription]; !ok {
+		return
+	}
+	delete(broker.subscribers, subscription)
+	close(subscription.done)
+}
+
+// Subscription is the stream of events of one subscriber
+type Subscription struct {
+	broker  *Broker
+	backlog []*proto.InvoiceEvent
+	events  chan *proto.InvoiceEvent
+	done    chan struct{}
+	// err is why the broker dropped the subscription, set before done is closed
+	err error
+}
+
+// Next waits for the next event. It fails with ErrSubscriberTooSlow once the
+// subscriber fell behind, after the buffered events were returned.
+func (subscription *Subscription) Next(ctx context.Context) (*proto.InvoiceEvent, error) {
+	if len(subscription.backlog) > 0 {
+		event := subscription.backlog[0]
+		subscription.backlog = subscription.backlog[1:]
+		return event, nil
+	}
+
+	select {
+	case event := <-subscription.events:
+		return event, nil
+	case <-subscription.done:
+		// events buffered before the subscription was dropped are still delivered
+		select {
+		case event := <-subscription.events:
+			return event, nil
+		default:
+		}
+		if subscription.err != nil {
+			return nil, subscription.err
+		}
+		return nil, errors.New("subscription is closed")
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Close stops the subscription
+func (subscription *Subscription) Close() {
+	subscription.broker.mutex.Lock()
+	defer subscription.broker.mutex.Unlock()
+
+	subscription.broker.unsubscribe(subscription)
+}
@@ -0,0 +1,187 @@
+package events_test
+
+import (
+	"context"
+	"fmt"
+	"grpc-2/events"
+	proto "grpc-2/invoice"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func publish(t *testing.T, broker *events.Broker, n int) {
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("inv-%d", i)
+		err := broker.Commit(proto.InvoiceEventType_INVOICE_EVENT_UPDATED, id, func() (*proto.InvoiceRecord, error) {
+			return &proto.InvoiceRecord{Id: id}, nil
+		})
+		require.NoError(t, err)
+	}
+}
+
+func next(t *testing.T, subscription *events.Subscription) *proto.InvoiceEvent {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	event, err := subscription.Next(ctx)
+	require.NoError(t, err)
+	return event
+}
+
+func TestBrokerSubscribe(t *testing.T) {
+	t.Parallel()
+
+	broker := events.NewBroker(10, 10)
+	publish(t, broker, 3)
+
+	// a new subscriber only sees new events
+	subscription, err := broker.Subscribe(0)
+	require.NoError(t, err)
+	defer subscription.Close()
+
+	publish(t, broker, 1)
+	event := next(t, subscription)
+	require.Equal(t, uint64(4), event.Sequence)
+	require.Equal(t, "inv-0", event.Invoice.Id)
+
+	// failed writes and writes without an invoice publish nothing
+	err = broker.Commit(proto.InvoiceEventType_INVOICE_EVENT_CREATED, "inv-1", func() (*proto.InvoiceRecord, error) {
+		return nil, fmt.Errorf("write failed")
+	})
+	require.Error(t, err)
+	err = broker.Commit(proto.InvoiceEventType_INVOICE_EVENT_PAID, "inv-1", func() (*proto.InvoiceRecord, error) {
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	publish(t, broker, 1)
+	require.Equal(t, uint64(5), next(t, subscription).Sequence)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = subscription.Next(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestBrokerResume(t *testing.T) {
+	t.Parallel()
+
+	broker := events.NewBroker(5, 10)
+	publish(t, broker, 8)
+
+	// events 4 to 8 are retained, so resuming after any of 3 to 8 works
+	subscription, err := broker.Subscribe(5)
+	require.NoError(t, err)
+	defer subscription.Close()
+
+	publish(t, broker, 1)
+	for sequence := uint64(6); sequence <= 9; sequence++ {
+		require.Equal(t, sequence, next(t, subscription).Sequence)
+	}
+
+	_, err = broker.Subscribe(3)
+	require.ErrorIs(t, err, events.ErrSequenceOutOfRange)
+	_, err = broker.Subscribe(10)
+	require.ErrorIs(t, err, events.ErrSequenceOutOfRange)
+
+	resumed, err := broker.Subscribe(9)
+	require.NoError(t, err)
+	resumed.Close()
+}
+
+func TestBrokerSlowSubscriber(t *testing.T) {
+	t.Parallel()
+
+	broker := events.NewBroker(100, 3)
+	slow, err := broker.Subscribe(0)
+	require.NoError(t, err)
+	defer slow.Close()
+
+	// the writer never blocks on a subscriber that does not read
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		publish(t, broker, 10)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("writer was blocked by a slow subscriber")
+	}
+
+	// the buffered events come first, then the subscriber learns where to resume
+	for sequence := uint64(1); sequence <= 3; sequence++ {
+		require.Equal(t, sequence, next(t, slow).Sequence)
+	}
+	_, err = slow.Next(context.Background())
+	require.ErrorIs(t, err, events.ErrSubscriberTooSlow)
+	require.Contains(t, err.Error(), "resume after sequence 3")
+
+	resumed, err := broker.Subscribe(3)
+	require.NoError(t, err)
+	defer resumed.Close()
+	for sequence := uint64(4); sequence <= 10; sequence++ {
+		require.Equal(t, sequence, next(t, resumed).Sequence)
+	}
+}
+
+func TestBrokerConcurrentWrites(t *testing.T) {
+	t.Parallel()
+
+	broker := events.NewBroker(100, 10)
+	subscription, err := broker.Subscribe(0)
+	require.NoError(t, err)
+	defer subscription.Close()
+
+	// a slow write holds back the other writes to its invoice only
+	started := make(chan struct{})
+	release := make(chan struct{})
+	slow := make(chan error, 1)
+	go func() {
+		slow <- broker.Commit(proto.InvoiceEventType_INVOICE_EVENT_UPDATED, "inv-1", func() (*proto.InvoiceRecord, error) {
+			close(started)
+			<-release
+			return &proto.InvoiceRecord{Id: "inv-1", PayerName: "first"}, nil
+		})
+	}()
+	<-started
+
+	other := make(chan error, 1)
+	go func() {
+		other <- broker.Commit(proto.InvoiceEventType_INVOICE_EVENT_UPDATED, "inv-2", func() (*proto.InvoiceRecord, error) {
+			return &proto.InvoiceRecord{Id: "inv-2"}, nil
+		})
+	}()
+	select {
+	case err := <-other:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("write to another invoice was blocked")
+	}
+
+	queued := make(chan error, 1)
+	go func() {
+		queued <- broker.Commit(proto.InvoiceEventType_INVOICE_EVENT_UPDATED, "inv-1", func() (*proto.InvoiceRecord, error) {
+			return &proto.InvoiceRecord{Id: "inv-1", PayerName: "second"}, nil
+		})
+	}()
+	select {
+	case <-queued:
+		t.Fatal("write to the same invoice did not wait")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	require.NoError(t, <-slow)
+	require.NoError(t, <-queued)
+
+	event := next(t, subscription)
+	require.Equal(t, "inv-2", event.Invoice.Id)
+	event = next(t, subscription)
+	require.Equal(t, "first", event.Invoice.PayerName)
+	event = next(t, subscription)
+	require.Equal(t, "second", event.Invoice.PayerName)
+	require.Equal(t, uint64(3), event.Sequence)
+}
@@ -19,10 +19,15 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	Invoice_GetInvoice_FullMethodName    = "/invoice.Invoice/GetInvoice"
-	Invoice_CreateInvoice_FullMethodName = "/invoice.Invoice/CreateInvoice"
-	Invoice_UpdateInvoice_FullMethodName = "/invoice.Invoice/UpdateInvoice"
-	Invoice_DeleteInvoice_FullMethodName = "/invoice.Invoice/DeleteInvoice"
+	Invoice_GetInvoice_FullMethodName          = "/invoice.Invoice/GetInvoice"
+	Invoice_CreateInvoice_FullMethodName       = "/invoice.Invoice/CreateInvoice"
+	Invoice_UpdateInvoice_FullMethodName       = "/invoice.Invoice/UpdateInvoice"
+	Invoice_DeleteInvoice_FullMethodName       = "/invoice.Invoice/DeleteInvoice"
+	Invoice_ListInvoices_FullMethodName        = "/invoice.Invoice/ListInvoices"
+	Invoice_PayInvoice_FullMethodName          = "/invoice.Invoice/PayInvoice"
+	Invoice_ChangeInvoiceStatus_FullMethodName = "/invoice.Invoice/ChangeInvoiceStatus"
+	Invoice_RenderInvoice_FullMethodName       = "/invoice.Invoice/RenderInvoice"
+	Invoice_WatchInvoices_FullMethodName       = "/invoice.Invoice/WatchInvoices"
 )
 
 // InvoiceClient is the client API for Invoice service.
@@ -33,6 +38,11 @@ type InvoiceClient interface {
 	CreateInvoice(ctx context.Context, in *CreateInvoiceRequest, opts ...grpc.CallOption) (*CreateInvoiceResponse, error)
 	UpdateInvoice(ctx context.Context, in *UpdateInvoiceRequest, opts ...grpc.CallOption) (*UpdateInvoiceResponse, error)
 	DeleteInvoice(ctx context.Context, in *DeleteInvoiceRequest, opts ...grpc.CallOption) (*DeleteInvoiceResponse, error)
+	ListInvoices(ctx context.Context, in *ListInvoicesRequest, opts ...grpc.CallOption) (*ListInvoicesResponse, error)
+	PayInvoice(ctx context.Context, in *PayInvoiceRequest, opts ...grpc.CallOption) (*PayInvoiceResponse, error)
+	ChangeInvoiceStatus(ctx context.Context, in *ChangeInvoiceStatusRequest, opts ...grpc.CallOption) (*ChangeInvoiceStatusResponse, error)
+	RenderInvoice(ctx context.Context, in *RenderInvoiceRequest, opts ...grpc.CallOption) (Invoice_RenderInvoiceClient, error)
+	WatchInvoices(ctx context.Context, in *WatchInvoicesRequest, opts ...grpc.CallOption) (Invoice_WatchInvoicesClient, error)
 }
 
 type invoiceClient struct {
@@ -83,6 +93,102 @@ func (c *invoiceClient) DeleteInvoice(ctx context.Context, in *DeleteInvoiceRequ
 	return out, nil
 }
 
+func (c *invoiceClient) ListInvoices(ctx context.Context, in *ListInvoicesRequest, opts ...grpc.CallOption) (*ListInvoicesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListInvoicesResponse)
+	err := c.cc.Invoke(ctx, Invoice_ListInvoices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *invoiceClient) PayInvoice(ctx context.Context, in *PayInvoiceRequest, opts ...grpc.CallOption) (*PayInvoiceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PayInvoiceResponse)
+	err := c.cc.Invoke(ctx, Invoice_PayInvoice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *invoiceClient) ChangeInvoiceStatus(ctx context.Context, in *ChangeInvoiceStatusRequest, opts ...grpc.CallOption) (*ChangeInvoiceStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangeInvoiceStatusResponse)
+	err := c.cc.Invoke(ctx, Invoice_ChangeInvoiceStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *invoiceClient) RenderInvoice(ctx context.Context, in *RenderInvoiceRequest, opts ...grpc.CallOption) (Invoice_RenderInvoiceClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Invoice_ServiceDesc.Streams[0], Invoice_RenderInvoice_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &invoiceRenderInvoiceClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Invoice_RenderInvoiceClient interface {
+	Recv() (*RenderInvoiceResponse, error)
+	grpc.ClientStream
+}
+
+type invoiceRenderInvoiceClient struct {
+	grpc.ClientStream
+}
+
+func (x *invoiceRenderInvoiceClient) Recv() (*RenderInvoiceResponse, error) {
+	m := new(RenderInvoiceResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *invoiceClient) WatchInvoices(ctx context.Context, in *WatchInvoicesRequest, opts ...grpc.CallOption) (Invoice_WatchInvoicesClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Invoice_ServiceDesc.Streams[1], Invoice_WatchInvoices_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &invoiceWatchInvoicesClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Invoice_WatchInvoicesClient interface {
+	Recv() (*InvoiceEvent, error)
+	grpc.ClientStream
+}
+
+type invoiceWatchInvoicesClient struct {
+	grpc.ClientStream
+}
+
+func (x *invoiceWatchInvoicesClient) Recv() (*InvoiceEvent, error) {
+	m := new(InvoiceEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // InvoiceServer is the server API for Invoice service.
 // All implementations must embed UnimplementedInvoiceServer
 // for forward compatibility
@@ -91,6 +197,11 @@ type InvoiceServer interface {
 	CreateInvoice(context.Context, *CreateInvoiceRequest) (*CreateInvoiceResponse, error)
 	UpdateInvoice(context.Context, *UpdateInvoiceRequest) (*UpdateInvoiceResponse, error)
 	DeleteInvoice(context.Context, *DeleteInvoiceRequest) (*DeleteInvoiceResponse, error)
+	ListInvoices(context.Context, *ListInvoicesRequest) (*ListInvoicesResponse, error)
+	PayInvoice(context.Context, *PayInvoiceRequest) (*PayInvoiceResponse, error)
+	ChangeInvoiceStatus(context.Context, *ChangeInvoiceStatusRequest) (*ChangeInvoiceStatusResponse, error)
+	RenderInvoice(*RenderInvoiceRequest, Invoice_RenderInvoiceServer) error
+	WatchInvoices(*WatchInvoicesRequest, Invoice_WatchInvoicesServer) error
 	mustEmbedUnimplementedInvoiceServer()
 }
 
@@ -110,6 +221,21 @@ func (UnimplementedInvoiceServer) UpdateInvoice(context.Context, *UpdateInvoiceR
 func (UnimplementedInvoiceServer) DeleteInvoice(context.Context, *DeleteInvoiceRequest) (*DeleteInvoiceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteInvoice not implemented")
 }
+func (UnimplementedInvoiceServer) ListInvoices(context.Context, *ListInvoicesRequest) (*ListInvoicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInvoices not implemented")
+}
+func (UnimplementedInvoiceServer) PayInvoice(context.Context, *PayInvoiceRequest) (*PayInvoiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PayInvoice not implemented")
+}
+func (UnimplementedInvoiceServer) ChangeInvoiceStatus(context.Context, *ChangeInvoiceStatusRequest) (*ChangeInvoiceStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeInvoiceStatus not implemented")
+}
+func (UnimplementedInvoiceServer) RenderInvoice(*RenderInvoiceRequest, Invoice_RenderInvoiceServer) error {
+	return status.Errorf(codes.Unimplemented, "method RenderInvoice not implemented")
+}
+func (UnimplementedInvoiceServer) WatchInvoices(*WatchInvoicesRequest, Invoice_WatchInvoicesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchInvoices not implemented")
+}
 func (UnimplementedInvoiceServer) mustEmbedUnimplementedInvoiceServer() {}
 
 // UnsafeInvoiceServer may be embedded to opt out of forward compatibility for this service.
@@ -195,6 +321,102 @@ func _Invoice_DeleteInvoice_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Invoice_ListInvoices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInvoicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InvoiceServer).ListInvoices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Invoice_ListInvoices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InvoiceServer).ListInvoices(ctx, req.(*ListInvoicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Invoice_PayInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PayInvoiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InvoiceServer).PayInvoice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Invoice_PayInvoice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InvoiceServer).PayInvoice(ctx, req.(*PayInvoiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Invoice_ChangeInvoiceStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeInvoiceStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InvoiceServer).ChangeInvoiceStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Invoice_ChangeInvoiceStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InvoiceServer).ChangeInvoiceStatus(ctx, req.(*ChangeInvoiceStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Invoice_RenderInvoice_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RenderInvoiceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(InvoiceServer).RenderInvoice(m, &invoiceRenderInvoiceServer{ServerStream: stream})
+}
+
+type Invoice_RenderInvoiceServer interface {
+	Send(*RenderInvoiceResponse) error
+	grpc.ServerStream
+}
+
+type invoiceRenderInvoiceServer struct {
+	grpc.ServerStream
+}
+
+func (x *invoiceRenderInvoiceServer) Send(m *RenderInvoiceResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Invoice_WatchInvoices_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchInvoicesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(InvoiceServer).WatchInvoices(m, &invoiceWatchInvoicesServer{ServerStream: stream})
+}
+
+type Invoice_WatchInvoicesServer interface {
+	Send(*InvoiceEvent) error
+	grpc.ServerStream
+}
+
+type invoiceWatchInvoicesServer struct {
+	grpc.ServerStream
+}
+
+func (x *invoiceWatchInvoicesServer) Send(m *InvoiceEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Invoice_ServiceDesc is the grpc.ServiceDesc for Invoice service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -218,7 +440,30 @@ var Invoice_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteInvoice",
 			Handler:    _Invoice_DeleteInvoice_Handler,
 		},
+		{
+			MethodName: "ListInvoices",
+			Handler:    _Invoice_ListInvoices_Handler,
+		},
+		{
+			MethodName: "PayInvoice",
+			Handler:    _Invoice_PayInvoice_Handler,
+		},
+		{
+			MethodName: "ChangeInvoiceStatus",
+			Handler:    _Invoice_ChangeInvoiceStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RenderInvoice",
+			Handler:       _Invoice_RenderInvoice_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchInvoices",
+			Handler:       _Invoice_WatchInvoices_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "invoice.proto",
 }
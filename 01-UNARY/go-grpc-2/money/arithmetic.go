@@ -0,0 +1,48 @@
+package money
+
+import (
+	"fmt"
+	"math"
+)
+
+// BasisPoints is the number of basis points in 100%
+const BasisPoints = 10000
+
+// Add returns a + b, or ErrInvalidAmount when the sum overflows
+func Add(a int64, b int64) (int64, error) {
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return 0, fmt.Errorf("%w: %d + %d overflows", ErrInvalidAmount, a, b)
+	}
+	return a + b, nil
+}
+
+// Multiply returns a * b, or ErrInvalidAmount when the product overflows
+func Multiply(a int64, b int64) (int64, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	product := a * b
+	if product/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, fmt.Errorf("%w: %d * %d overflows", ErrInvalidAmount, a, b)
+	}
+	return product, nil
+}
+
+// ApplyRate returns rate basis points of a non-negative amount, rounded half
+// up to the nearest minor unit. 2000 basis points of 1005 is 201.
+func ApplyRate(amount int64, rate int64) (int64, error) {
+	if amount < 0 || rate < 0 || rate > math.MaxInt32 {
+		return 0, fmt.Errorf("%w: rate %d of %d", ErrInvalidAmount, rate, amount)
+	}
+
+	// split amount so the intermediate product cannot overflow
+	whole := amount / BasisPoints
+	rest := amount % BasisPoints
+
+	result, err := Multiply(whole, rate)
+	if err != nil {
+		return 0, err
+	}
+	// rest is below 10^4 and rate below 2^31, their product fits easily
+	return Add(result, (rest*rate+BasisPoints/2)/BasisPoints)
+}
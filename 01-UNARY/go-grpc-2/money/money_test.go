@@ -0,0 +1,91 @@
+package money_test
+
+import (
+	"grpc-2/money"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	currency, err := money.Lookup("JPY")
+	require.NoError(t, err)
+	require.Equal(t, 0, currency.Digits)
+
+	for _, code := range []string{"", "eur", "EURO", "XAU", "test"} {
+		_, err = money.Lookup(code)
+		require.ErrorIs(t, err, money.ErrUnknownCurrency)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		currency string
+		minor    int64
+		amount   string
+	}{
+		{currency: "EUR", minor: 1200, amount: "12.00"},
+		{currency: "EUR", minor: 1230, amount: "12.30"},
+		{currency: "EUR", minor: 5, amount: "0.05"},
+		{currency: "EUR", minor: 0, amount: "0.00"},
+		{currency: "EUR", minor: -1230, amount: "-12.30"},
+		{currency: "EUR", minor: math.MinInt64, amount: "-92233720368547758.08"},
+		{currency: "JPY", minor: 1230, amount: "1230"},
+		{currency: "BHD", minor: 1234, amount: "1.234"},
+	}
+
+	for _, tc := range testCases {
+		currency, err := money.Lookup(tc.currency)
+		require.NoError(t, err)
+		require.Equal(t, tc.amount, currency.Format(tc.minor))
+	}
+}
+
+func TestApplyRate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		amount int64
+		rate   int64
+		result int64
+	}{
+		{amount: 1005, rate: 2000, result: 201},
+		{amount: 1015, rate: 2000, result: 203},
+		{amount: 25, rate: 2000, result: 5},
+		{amount: 1, rate: 5000, result: 1},
+		{amount: 1, rate: 4999, result: 0},
+		{amount: 0, rate: 2000, result: 0},
+		{amount: math.MaxInt64 / 2, rate: 10000, result: math.MaxInt64 / 2},
+	}
+
+	for _, tc := range testCases {
+		result, err := money.ApplyRate(tc.amount, tc.rate)
+		require.NoError(t, err)
+		require.Equal(t, tc.result, result, "%d basis points of %d", tc.rate, tc.amount)
+	}
+
+	_, err := money.ApplyRate(math.MaxInt64, 20000)
+	require.ErrorIs(t, err, money.ErrInvalidAmount)
+	_, err = money.ApplyRate(-1, 2000)
+	require.ErrorIs(t, err, money.ErrInvalidAmount)
+}
+
+func TestOverflow(t *testing.T) {
+	t.Parallel()
+
+	_, err := money.Add(math.MaxInt64, 1)
+	require.ErrorIs(t, err, money.ErrInvalidAmount)
+	_, err = money.Multiply(math.MaxInt64, 2)
+	require.ErrorIs(t, err, money.ErrInvalidAmount)
+	_, err = money.Multiply(-1, math.MinInt64)
+	require.ErrorIs(t, err, money.ErrInvalidAmount)
+
+	product, err := money.Multiply(1<<31, 1<<31)
+	require.NoError(t, err)
+	require.Equal(t, int64(1<<62), product)
+}
@@ -0,0 +1,123 @@
+package render
+
+import (
+	"fmt"
+	proto "grpc-2/invoice"
+	"io"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// page layout in millimetres on A4
+const (
+	pageMargin = 20.0
+	lineHeight = 6.0
+	// widths of the line item columns, they add up to the printable width
+	descriptionWidth = 78.0
+	quantityWidth    = 20.0
+	unitPriceWidth   = 26.0
+	taxRateWidth     = 20.0
+	amountWidth      = 26.0
+)
+
+// PDF writes invoice to w as an A4 PDF. Only the core PDF fonts are used, so
+// no font files are needed, and the document dates are the invoice dates
+// rather than the time of rendering.
+func PDF(w io.Writer, invoice *proto.InvoiceRecord) error {
+	v := newView(invoice)
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pageMargin, pageMargin, pageMargin)
+	pdf.SetAutoPageBreak(true, pageMargin)
+	pdf.SetCatalogSort(true)
+	pdf.SetCreationDate(invoice.GetCreatedAt().AsTime())
+	pdf.SetModificationDate(invoice.GetUpdatedAt().AsTime())
+	pdf.SetTitle("Invoice "+v.ID, true)
+	pdf.SetProducer("grpc-2 invoice service", true)
+
+	// the core fonts are encoded in cp1252
+	text := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 20)
+	pdf.CellFormat(0, 10, "Invoice", "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.SetTextColor(102, 102, 102)
+	pdf.CellFormat(0, lineHeight, text(fmt.Sprintf("%s - %s - issued %s", v.ID, v.Status, v.IssuedOn)), "", 1, "L", false, 0, "")
+	pdf.SetTextColor(34, 34, 34)
+	pdf.Ln(lineHeight)
+
+	pdf.SetFont("Helvetica", "B", 12)
+	pdf.CellFormat(0, lineHeight, "Bill to", "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	if v.PayerName != "" {
+		pdf.CellFormat(0, lineHeight, text(v.PayerName), "", 1, "L", false, 0, "")
+	}
+	pdf.CellFormat(0, lineHeight, text(v.PayerEmail), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, lineHeight, text("Payer "+v.PayerID), "", 1, "L", false, 0, "")
+	if v.Description != "" {
+		pdf.Ln(lineHeight / 2)
+		pdf.MultiCell(0, lineHeight, text(v.Description), "", "L", false)
+	}
+	pdf.Ln(lineHeight)
+
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(descriptionWidth, lineHeight, "Description", "B", 0, "L", false, 0, "")
+	pdf.CellFormat(quantityWidth, lineHeight, "Quantity", "B", 0, "R", false, 0, "")
+	pdf.CellFormat(unitPriceWidth, lineHeight, "Unit price", "B", 0, "R", false, 0, "")
+	pdf.CellFormat(taxRateWidth, lineHeight, "Tax", "B", 0, "R", false, 0, "")
+	pdf.CellFormat(amountWidth, lineHeight, "Amount", "B", 1, "R", false, 0, "")
+
+	pdf.SetFont("Helvetica", "", 10)
+	for _, line := range v.Lines {
+		pdf.CellFormat(descriptionWidth, lineHeight, text(line.Description), "B", 0, "L", false, 0, "")
+		pdf.CellFormat(quantityWidth, lineHeight, line.Quantity, "B", 0, "R", false, 0, "")
+		pdf.CellFormat(unitPriceWidth, lineHeight, line.UnitPrice, "B", 0, "R", false, 0, "")
+		pdf.CellFormat(taxRateWidth, lineHeight, line.TaxRate, "B", 0, "R", false, 0, "")
+		pdf.CellFormat(amountWidth, lineHeight, line.Total, "B", 1, "R", false, 0, "")
+	}
+
+	totals := []struct {
+		label string
+		value string
+		bold  bool
+	}{
+		{label: "Subtotal", value: v.Subtotal},
+		{label: "Tax", value: v.Tax},
+		{label: "Total", value: v.Total, bold: true},
+		{label: "Paid", value: v.AmountPaid},
+		{label: "Amount due", value: v.AmountDue, bold: true},
+	}
+	labelWidth := descriptionWidth + quantityWidth + unitPriceWidth + taxRateWidth
+	for _, total := range totals {
+		style := ""
+		if total.bold {
+			style = "B"
+		}
+		pdf.SetFont("Helvetica", style, 10)
+		pdf.CellFormat(labelWidth, lineHeight, total.label, "", 0, "R", false, 0, "")
+		pdf.CellFormat(amountWidth, lineHeight, total.value+" "+v.Currency, "", 1, "R", false, 0, "")
+	}
+
+	if len(v.Metadata) > 0 {
+		pdf.Ln(lineHeight)
+		pdf.SetFont("Helvetica", "B", 12)
+		pdf.CellFormat(0, lineHeight, "Details", "", 1, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 10)
+		for _, pair := range v.Metadata {
+			pdf.CellFormat(0, lineHeight, text(pair.Key+": "+pair.Value), "", 1, "L", false, 0, "")
+		}
+	}
+
+	pdf.Ln(lineHeight)
+	pdf.SetFont("Helvetica", "", 8)
+	pdf.SetTextColor(102, 102, 102)
+	pdf.CellFormat(0, lineHeight, "Version "+v.Version, "", 1, "L", false, 0, "")
+
+	err := pdf.Output(w)
+	if err != nil {
+		return fmt.Errorf("cannot render pdf: %w", err)
+	}
+	return nil
+}
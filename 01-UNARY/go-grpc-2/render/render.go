@@ -0,0 +1,64 @@
+package render
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	proto "grpc-2/invoice"
+	"html/template"
+	"io"
+)
+
+// ErrUnknownFormat is returned for a format that cannot be rendered
+var ErrUnknownFormat = errors.New("unknown render format")
+
+//go:embed templates/invoice.html.tmpl
+var templates embed.FS
+
+var invoiceTemplate = template.Must(template.ParseFS(templates, "templates/invoice.html.tmpl"))
+
+// Document is a rendered invoice
+type Document struct {
+	ContentType string
+	Filename    string
+	Data        []byte
+}
+
+// Render renders invoice in format. The output depends only on the invoice,
+// so the same version of an invoice always renders to the same bytes.
+func Render(invoice *proto.InvoiceRecord, format proto.RenderFormat) (*Document, error) {
+	buffer := &bytes.Buffer{}
+
+	var contentType, extension string
+	var err error
+	switch format {
+	case proto.RenderFormat_RENDER_FORMAT_HTML:
+		contentType, extension = "text/html; charset=utf-8", ".html"
+		err = HTML(buffer, invoice)
+	case proto.RenderFormat_RENDER_FORMAT_PDF:
+		contentType, extension = "application/pdf", ".pdf"
+		err = PDF(buffer, invoice)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	document := &Document{
+		ContentType: contentType,
+		Filename:    "invoice-" + invoice.GetId() + extension,
+		Data:        buffer.Bytes(),
+	}
+	return document, nil
+}
+
+// HTML writes invoice to w as an HTML page
+func HTML(w io.Writer, invoice *proto.InvoiceRecord) error {
+	err := invoiceTemplate.Execute(w, newView(invoice))
+	if err != nil {
+		return fmt.Errorf("cannot render html: %w", err)
+	}
+	return nil
+}
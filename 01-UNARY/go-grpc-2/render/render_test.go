@@ -0,0 +1,90 @@
+package render_test
+
+import (
+	"flag"
+	proto "grpc-2/invoice"
+	"grpc-2/render"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func newInvoice() *proto.InvoiceRecord {
+	createdAt := time.Date(2024, time.March, 1, 9, 30, 0, 0, time.UTC)
+	return &proto.InvoiceRecord{
+		Id:          "inv-2024-0001",
+		PayerId:     "acme-1",
+		PayerName:   "Zoë Müller",
+		PayerEmail:  "zoe@example.com",
+		Currency:    "EUR",
+		Description: "Consulting for March & travel expenses",
+		Status:      proto.InvoiceStatus_INVOICE_STATUS_PARTIALLY_PAID,
+		CreatedAt:   timestamppb.New(createdAt),
+		UpdatedAt:   timestamppb.New(createdAt.Add(48 * time.Hour)),
+		LineItems: []*proto.LineItem{
+			{Description: "Consulting", Quantity: 3, UnitPrice: 2500, TaxRate: 2000, Subtotal: 7500, Tax: 1500, Total: 9000},
+			{Description: "Travel <train>", Quantity: 1, UnitPrice: 1999, TaxRate: 550, Subtotal: 1999, Tax: 110, Total: 2109},
+		},
+		Subtotal:   9499,
+		Tax:        1610,
+		Total:      11109,
+		AmountPaid: 4000,
+		Metadata:   map[string]string{"project": "apollo", "cost-center": "42"},
+	}
+}
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		format      proto.RenderFormat
+		golden      string
+		contentType string
+	}{
+		{format: proto.RenderFormat_RENDER_FORMAT_HTML, golden: "invoice.golden.html", contentType: "text/html; charset=utf-8"},
+		{format: proto.RenderFormat_RENDER_FORMAT_PDF, golden: "invoice.golden.pdf", contentType: "application/pdf"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.format.String(), func(t *testing.T) {
+			t.Parallel()
+
+			document, err := render.Render(newInvoice(), tc.format)
+			require.NoError(t, err)
+			require.Equal(t, tc.contentType, document.ContentType)
+
+			again, err := render.Render(newInvoice(), tc.format)
+			require.NoError(t, err)
+			require.Equal(t, document.Data, again.Data)
+
+			golden := filepath.Join("testdata", tc.golden)
+			if *update {
+				require.NoError(t, os.WriteFile(golden, document.Data, 0644))
+			}
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			require.Equal(t, expected, document.Data, "run go test -update to accept the new output")
+
+			// a new version of the invoice renders differently
+			changed := newInvoice()
+			changed.UpdatedAt = timestamppb.New(changed.UpdatedAt.AsTime().Add(time.Second))
+			changedDocument, err := render.Render(changed, tc.format)
+			require.NoError(t, err)
+			require.NotEqual(t, document.Data, changedDocument.Data)
+		})
+	}
+}
+
+func TestRenderUnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	_, err := render.Render(newInvoice(), proto.RenderFormat_RENDER_FORMAT_UNSPECIFIED)
+	require.ErrorIs(t, err, render.ErrUnknownFormat)
+}
@@ -0,0 +1,98 @@
+package render
+
+import (
+	proto "grpc-2/invoice"
+	"grpc-2/money"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// view is an invoice with every value formatted for display. Both the HTML
+// template and the PDF layout render from it, so the two formats always show
+// the same figures.
+type view struct {
+	ID          string
+	Status      string
+	IssuedOn    string
+	Version     string
+	PayerID     string
+	PayerName   string
+	PayerEmail  string
+	Description string
+	Currency    string
+	Lines       []lineView
+	Subtotal    string
+	Tax         string
+	Total       string
+	AmountPaid  string
+	AmountDue   string
+	Metadata    []pair
+}
+
+type lineView struct {
+	Description string
+	Quantity    string
+	UnitPrice   string
+	TaxRate     string
+	Total       string
+}
+
+type pair struct {
+	Key   string
+	Value string
+}
+
+func newView(invoice *proto.InvoiceRecord) view {
+	currency, err := money.Lookup(invoice.GetCurrency())
+	if err != nil {
+		currency = money.Currency{Code: invoice.GetCurrency(), Digits: 2}
+	}
+
+	v := view{
+		ID:          invoice.GetId(),
+		Status:      statusLabel(invoice.GetStatus()),
+		IssuedOn:    invoice.GetCreatedAt().AsTime().UTC().Format("2 January 2006"),
+		Version:     invoice.GetUpdatedAt().AsTime().UTC().Format("2006-01-02 15:04:05 UTC"),
+		PayerID:     invoice.GetPayerId(),
+		PayerName:   invoice.GetPayerName(),
+		PayerEmail:  invoice.GetPayerEmail(),
+		Description: invoice.GetDescription(),
+		Currency:    currency.Code,
+		Subtotal:    currency.Format(invoice.GetSubtotal()),
+		Tax:         currency.Format(invoice.GetTax()),
+		Total:       currency.Format(invoice.GetTotal()),
+		AmountPaid:  currency.Format(invoice.GetAmountPaid()),
+		AmountDue:   currency.Format(invoice.GetTotal() - invoice.GetAmountPaid()),
+	}
+
+	for _, item := range invoice.GetLineItems() {
+		v.Lines = append(v.Lines, lineView{
+			Description: item.GetDescription(),
+			Quantity:    strconv.FormatInt(item.GetQuantity(), 10),
+			UnitPrice:   currency.Format(item.GetUnitPrice()),
+			TaxRate:     strconv.FormatFloat(float64(item.GetTaxRate())/100, 'f', -1, 64) + "%",
+			Total:       currency.Format(item.GetTotal()),
+		})
+	}
+
+	// map order is random, the output must not be
+	for key, value := range invoice.GetMetadata() {
+		v.Metadata = append(v.Metadata, pair{Key: key, Value: value})
+	}
+	sort.Slice(v.Metadata, func(i, j int) bool {
+		return v.Metadata[i].Key < v.Metadata[j].Key
+	})
+
+	return v
+}
+
+// statusLabel turns INVOICE_STATUS_PARTIALLY_PAID into "Partially paid"
+func statusLabel(status proto.InvoiceStatus) string {
+	label := strings.ToLower(strings.TrimPrefix(status.String(), "INVOICE_STATUS_"))
+	label = strings.ReplaceAll(label, "_", " ")
+	if label == "" {
+		return ""
+	}
+	return strings.ToUpper(label[:1]) + label[1:]
+}
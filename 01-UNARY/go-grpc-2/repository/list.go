@@ -0,0 +1,105 @@
+package repository
+
+import (
+	proto "grpc-2/invoice"
+	"strings"
+	"time"
+)
+
+// ListQuery selects a page of invoices. Zero-valued filters match every invoice.
+type ListQuery struct {
+	PayerID  string
+	Currency string
+	Status   proto.InvoiceStatus
+	// Metadata matches invoices that have every one of these exact pairs
+	Metadata map[string]string
+	// CreatedAfter is inclusive and CreatedBefore is exclusive
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+
+	// SortBy amount compares totals in minor units, which only makes sense
+	// together with a Currency filter
+	SortBy     proto.InvoiceSortField
+	Descending bool
+
+	// After resumes the listing behind this position, nil starts from the beginning
+	After *Cursor
+	// Limit is the maximum number of invoices returned
+	Limit int
+}
+
+// Cursor is the position of an invoice in a listing. The id breaks ties
+// between invoices with the same sort key, so every position is unique and
+// invoices inserted while paging never shift the pages that follow.
+type Cursor struct {
+	CreatedAt int64  `json:"c,omitempty"`
+	Total     int64  `json:"t,omitempty"`
+	ID        string `json:"i"`
+}
+
+// NewCursor returns the position of invoice in a listing
+func NewCursor(invoice *proto.InvoiceRecord) Cursor {
+	return Cursor{
+		CreatedAt: invoice.GetCreatedAt().AsTime().UnixNano(),
+		Total:     invoice.GetTotal(),
+		ID:        invoice.GetId(),
+	}
+}
+
+// matches reports whether invoice passes the filters of query
+func (query ListQuery) matches(invoice *proto.InvoiceRecord) bool {
+	if query.PayerID != "" && invoice.GetPayerId() != query.PayerID {
+		return false
+	}
+	if query.Currency != "" && invoice.GetCurrency() != query.Currency {
+		return false
+	}
+	if query.Status != proto.InvoiceStatus_INVOICE_STATUS_UNSPECIFIED && invoice.GetStatus() != query.Status {
+		return false
+	}
+	for key, value := range query.Metadata {
+		if stored, ok := invoice.GetMetadata()[key]; !ok || stored != value {
+			return false
+		}
+	}
+
+	createdAt := invoice.GetCreatedAt().AsTime()
+	if !query.CreatedAfter.IsZero() && createdAt.Before(query.CreatedAfter) {
+		return false
+	}
+	if !query.CreatedBefore.IsZero() && !createdAt.Before(query.CreatedBefore) {
+		return false
+	}
+
+	return true
+}
+
+// compare orders two cursors by the sort key of query, then by id
+func (query ListQuery) compare(a Cursor, b Cursor) int {
+	result := 0
+	switch query.SortBy {
+	case proto.InvoiceSortField_INVOICE_SORT_AMOUNT:
+		result = compareValues(a.Total, b.Total)
+	default:
+		result = compareValues(a.CreatedAt, b.CreatedAt)
+	}
+	if result == 0 {
+		result = strings.Compare(a.ID, b.ID)
+	}
+
+	if query.Descending {
+		return -result
+	}
+	return result
+}
+
+func compareValues(a int64, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
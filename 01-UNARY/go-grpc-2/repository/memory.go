@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	proto "grpc-2/invoice"
+	"sort"
+	"sync"
+
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// MemoryRepository keeps invoices in memory
+type MemoryRepository struct {
+	mutex    sync.RWMutex
+	invoices map[string]*proto.InvoiceRecord
+}
+
+// NewMemoryRepository creates an empty MemoryRepository
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		invoices: make(map[string]*proto.InvoiceRecord),
+	}
+}
+
+func (repo *MemoryRepository) Create(ctx context.Context, invoice *proto.InvoiceRecord) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if _, ok := repo.invoices[invoice.Id]; ok {
+		return ErrAlreadyExists
+	}
+
+	repo.invoices[invoice.Id] = clone(invoice)
+	return nil
+}
+
+func (repo *MemoryRepository) Get(ctx context.Context, id string) (*proto.InvoiceRecord, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	invoice, ok := repo.invoices[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return clone(invoice), nil
+}
+
+func (repo *MemoryRepository) Update(ctx context.Context, invoice *proto.InvoiceRecord) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if _, ok := repo.invoices[invoice.Id]; !ok {
+		return ErrNotFound
+	}
+
+	repo.invoices[invoice.Id] = clone(invoice)
+	return nil
+}
+
+func (repo *MemoryRepository) Mutate(ctx context.Context, id string, fn func(invoice *proto.InvoiceRecord) error) (*proto.InvoiceRecord, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	stored, ok := repo.invoices[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	invoice := clone(stored)
+	err := fn(invoice)
+	if err != nil {
+		return nil, err
+	}
+
+	repo.invoices[id] = clone(invoice)
+	return invoice, nil
+}
+
+func (repo *MemoryRepository) Delete(ctx context.Context, id string) (*proto.InvoiceRecord, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	invoice, ok := repo.invoices[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	delete(repo.invoices, id)
+	return invoice, nil
+}
+
+func (repo *MemoryRepository) List(ctx context.Context, query ListQuery) ([]*proto.InvoiceRecord, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	matched := []*proto.InvoiceRecord{}
+	for _, invoice := range repo.invoices {
+		if !query.matches(invoice) {
+			continue
+		}
+		if query.After != nil && query.compare(NewCursor(invoice), *query.After) <= 0 {
+			continue
+		}
+		matched = append(matched, invoice)
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		return query.compare(NewCursor(matched[i]), NewCursor(matched[j])) < 0
+	})
+
+	if query.Limit > 0 && len(matched) > query.Limit {
+		matched = matched[:query.Limit]
+	}
+
+	invoices := make([]*proto.InvoiceRecord, len(matched))
+	for i, invoice := range matched {
+		invoices[i] = clone(invoice)
+	}
+	return invoices, nil
+}
+
+// clone deep copies an invoice so callers never share it with the repository
+func clone(invoice *proto.InvoiceRecord) *proto.InvoiceRecord {
+	return protobuf.Clone(invoice).(*proto.InvoiceRecord)
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	proto "grpc-2/invoice"
+)
+
+var (
+	ErrNotFound      = errors.New("invoice not found")
+	ErrAlreadyExists = errors.New("invoice already exists")
+)
+
+// InvoiceRepository persists invoices
+type InvoiceRepository interface {
+	// Create stores a new invoice, returns ErrAlreadyExists if its id is taken
+	Create(ctx context.Context, invoice *proto.InvoiceRecord) error
+	// Get finds an invoice by id, returns ErrNotFound if it does not exist
+	Get(ctx context.Context, id string) (*proto.InvoiceRecord, error)
+	// Update replaces a stored invoice, returns ErrNotFound if it does not exist
+	Update(ctx context.Context, invoice *proto.InvoiceRecord) error
+	// Delete removes an invoice by id and returns it, or ErrNotFound
+	Delete(ctx context.Context, id string) (*proto.InvoiceRecord, error)
+	// Mutate applies fn to the stored invoice and saves the result, all in one
+	// atomic step. Nothing is saved when fn returns an error.
+	Mutate(ctx context.Context, id string, fn func(invoice *proto.InvoiceRecord) error) (*proto.InvoiceRecord, error)
+	// List returns the invoices matching query in its sort order
+	List(ctx context.Context, query ListQuery) ([]*proto.InvoiceRecord, error)
+}
@@ -0,0 +1,270 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	proto "grpc-2/invoice"
+	"grpc-2/repository"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// newRepositories returns one repository of each implementation
+func newRepositories(t *testing.T) map[string]repository.InvoiceRepository {
+	sqliteRepo, err := repository.NewSQLiteRepository(filepath.Join(t.TempDir(), "invoices.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { sqliteRepo.Close() })
+
+	return map[string]repository.InvoiceRepository{
+		"memory": repository.NewMemoryRepository(),
+		"sqlite": sqliteRepo,
+	}
+}
+
+func TestRepository(t *testing.T) {
+	t.Parallel()
+
+	for name, repo := range newRepositories(t) {
+		repo := repo
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			invoice := &proto.InvoiceRecord{
+				Id:         "inv-1",
+				PayerId:    "payer-1",
+				PayerName:  "Jane Doe",
+				PayerEmail: "jane@example.com",
+				Currency:   "EUR",
+				Total:      1000,
+				CreatedAt:  timestamppb.Now(),
+				UpdatedAt:  timestamppb.Now(),
+			}
+
+			err := repo.Create(ctx, invoice)
+			require.NoError(t, err)
+			err = repo.Create(ctx, invoice)
+			require.ErrorIs(t, err, repository.ErrAlreadyExists)
+
+			got, err := repo.Get(ctx, invoice.Id)
+			require.NoError(t, err)
+			require.True(t, protobuf.Equal(invoice, got))
+
+			got.Total = 1250
+			err = repo.Update(ctx, got)
+			require.NoError(t, err)
+			err = repo.Update(ctx, &proto.InvoiceRecord{Id: "missing"})
+			require.ErrorIs(t, err, repository.ErrNotFound)
+
+			deleted, err := repo.Delete(ctx, invoice.Id)
+			require.NoError(t, err)
+			require.Equal(t, int64(1250), deleted.Total)
+
+			_, err = repo.Get(ctx, invoice.Id)
+			require.ErrorIs(t, err, repository.ErrNotFound)
+			_, err = repo.Delete(ctx, invoice.Id)
+			require.ErrorIs(t, err, repository.ErrNotFound)
+		})
+	}
+}
+
+func TestRepositoryMutate(t *testing.T) {
+	t.Parallel()
+
+	for name, repo := range newRepositories(t) {
+		repo := repo
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			invoice := &proto.InvoiceRecord{
+				Id:        "inv-1",
+				Total:     1000,
+				Status:    proto.InvoiceStatus_INVOICE_STATUS_DRAFT,
+				CreatedAt: timestamppb.Now(),
+			}
+			require.NoError(t, repo.Create(ctx, invoice))
+
+			mutated, err := repo.Mutate(ctx, invoice.Id, func(invoice *proto.InvoiceRecord) error {
+				invoice.Status = proto.InvoiceStatus_INVOICE_STATUS_ISSUED
+				return nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, proto.InvoiceStatus_INVOICE_STATUS_ISSUED, mutated.Status)
+
+			// a failed mutation leaves the stored invoice untouched
+			errRejected := errors.New("rejected")
+			_, err = repo.Mutate(ctx, invoice.Id, func(invoice *proto.InvoiceRecord) error {
+				invoice.Status = proto.InvoiceStatus_INVOICE_STATUS_VOIDED
+				return errRejected
+			})
+			require.ErrorIs(t, err, errRejected)
+
+			got, err := repo.Get(ctx, invoice.Id)
+			require.NoError(t, err)
+			require.Equal(t, proto.InvoiceStatus_INVOICE_STATUS_ISSUED, got.Status)
+
+			_, err = repo.Mutate(ctx, "missing", func(invoice *proto.InvoiceRecord) error { return nil })
+			require.ErrorIs(t, err, repository.ErrNotFound)
+
+			// concurrent mutations are applied one after another
+			const n = 20
+			var wg sync.WaitGroup
+			for i := 0; i < n; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, err := repo.Mutate(ctx, invoice.Id, func(invoice *proto.InvoiceRecord) error {
+						invoice.Payments = append(invoice.Payments, &proto.Payment{})
+						return nil
+					})
+					require.NoError(t, err)
+				}()
+			}
+			wg.Wait()
+
+			got, err = repo.Get(ctx, invoice.Id)
+			require.NoError(t, err)
+			require.Len(t, got.Payments, n)
+		})
+	}
+}
+
+func TestRepositoryList(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	for name, repo := range newRepositories(t) {
+		repo := repo
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			for i := 0; i < 10; i++ {
+				invoice := &proto.InvoiceRecord{
+					Id:        fmt.Sprintf("inv-%02d", i),
+					PayerId:   []string{"payer-a", "payer-b"}[i%2],
+					Currency:  "EUR",
+					Total:     int64(10050 - i*1000),
+					Status:    proto.InvoiceStatus_INVOICE_STATUS_DRAFT,
+					CreatedAt: timestamppb.New(base.Add(time.Duration(i/2) * time.Hour)),
+					Metadata:  map[string]string{"batch": fmt.Sprint(i % 3), "project": "apollo"},
+				}
+				require.NoError(t, repo.Create(ctx, invoice))
+			}
+
+			// pages do not overlap or skip invoices, even when invoices are
+			// inserted between two pages
+			query := repository.ListQuery{Limit: 3}
+			seen := []string{}
+			for page := 0; ; page++ {
+				invoices, err := repo.List(ctx, query)
+				require.NoError(t, err)
+				for _, invoice := range invoices {
+					seen = append(seen, invoice.Id)
+				}
+				if len(invoices) < query.Limit {
+					break
+				}
+
+				cursor := repository.NewCursor(invoices[len(invoices)-1])
+				query.After = &cursor
+
+				if page == 0 {
+					err = repo.Create(ctx, &proto.InvoiceRecord{
+						Id:        fmt.Sprintf("early-%d", page),
+						CreatedAt: timestamppb.New(base.Add(-time.Hour)),
+					})
+					require.NoError(t, err)
+				}
+			}
+			require.Equal(t, []string{
+				"inv-00", "inv-01", "inv-02", "inv-03", "inv-04",
+				"inv-05", "inv-06", "inv-07", "inv-08", "inv-09",
+			}, seen)
+
+			invoices, err := repo.List(ctx, repository.ListQuery{
+				PayerID:    "payer-b",
+				Currency:   "EUR",
+				SortBy:     proto.InvoiceSortField_INVOICE_SORT_AMOUNT,
+				Descending: true,
+			})
+			require.NoError(t, err)
+			require.Equal(t, []string{"inv-01", "inv-03", "inv-05", "inv-07", "inv-09"}, ids(invoices))
+
+			// totals beyond the precision of a float64 still sort and page exactly
+			for i, total := range []int64{1<<53 + 1, 1 << 53} {
+				err = repo.Create(ctx, &proto.InvoiceRecord{
+					Id:        fmt.Sprintf("big-%d", i),
+					Currency:  "JPY",
+					Total:     total,
+					CreatedAt: timestamppb.New(base),
+				})
+				require.NoError(t, err)
+			}
+			amountQuery := repository.ListQuery{
+				Currency: "JPY",
+				SortBy:   proto.InvoiceSortField_INVOICE_SORT_AMOUNT,
+				Limit:    1,
+			}
+			invoices, err = repo.List(ctx, amountQuery)
+			require.NoError(t, err)
+			require.Equal(t, []string{"big-1"}, ids(invoices))
+			cursor := repository.NewCursor(invoices[0])
+			amountQuery.After = &cursor
+			invoices, err = repo.List(ctx, amountQuery)
+			require.NoError(t, err)
+			require.Equal(t, []string{"big-0"}, ids(invoices))
+
+			invoices, err = repo.List(ctx, repository.ListQuery{
+				Currency:      "EUR",
+				Status:        proto.InvoiceStatus_INVOICE_STATUS_DRAFT,
+				CreatedAfter:  base.Add(time.Hour),
+				CreatedBefore: base.Add(3 * time.Hour),
+			})
+			require.NoError(t, err)
+			require.Equal(t, []string{"inv-02", "inv-03", "inv-04", "inv-05"}, ids(invoices))
+
+			invoices, err = repo.List(ctx, repository.ListQuery{
+				Metadata: map[string]string{"batch": "1", "project": "apollo"},
+			})
+			require.NoError(t, err)
+			require.Equal(t, []string{"inv-01", "inv-04", "inv-07"}, ids(invoices))
+
+			// metadata rows follow updates and deletes
+			updated, err := repo.Mutate(ctx, "inv-04", func(invoice *proto.InvoiceRecord) error {
+				invoice.Metadata = map[string]string{"batch": "2"}
+				return nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, "2", updated.Metadata["batch"])
+			_, err = repo.Delete(ctx, "inv-07")
+			require.NoError(t, err)
+
+			invoices, err = repo.List(ctx, repository.ListQuery{
+				Metadata: map[string]string{"batch": "1"},
+			})
+			require.NoError(t, err)
+			require.Equal(t, []string{"inv-01"}, ids(invoices))
+		})
+	}
+}
+
+func ids(invoices []*proto.InvoiceRecord) []string {
+	result := []string{}
+	for _, invoice := range invoices {
+		result = append(result, invoice.Id)
+	}
+	return result
+}
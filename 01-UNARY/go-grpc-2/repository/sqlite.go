@@ -0,0 +1,367 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	proto "grpc-2/invoice"
+	"strings"
+
+	"github.com/mattn/go-sqlite3"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// migrations are applied in order, PRAGMA user_version records how many ran
+var migrations = []string{
+	`CREATE TABLE invoices (
+		id         TEXT PRIMARY KEY,
+		payer_id   TEXT NOT NULL,
+		currency   TEXT NOT NULL,
+		total      INTEGER NOT NULL,
+		status     INTEGER NOT NULL,
+		created_at INTEGER NOT NULL,
+		updated_at INTEGER NOT NULL,
+		data       BLOB NOT NULL
+	);
+	CREATE INDEX invoices_payer_id ON invoices (payer_id);
+	CREATE INDEX invoices_created_at ON invoices (created_at, id);
+	CREATE INDEX invoices_currency_total ON invoices (currency, total, id);
+	CREATE TABLE invoice_metadata (
+		invoice_id TEXT NOT NULL,
+		key        TEXT NOT NULL,
+		value      TEXT NOT NULL,
+		PRIMARY KEY (invoice_id, key)
+	);
+	CREATE INDEX invoice_metadata_key_value ON invoice_metadata (key, value);`,
+}
+
+// SQLiteRepository stores invoices in a SQLite database. The full invoice is
+// kept as an encoded protobuf, next to the columns that queries filter on.
+type SQLiteRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteRepository opens the database at path, creating and migrating it if needed
+func NewSQLiteRepository(path string) (*SQLiteRepository, error) {
+	// immediate transactions take the write lock up front, so that two
+	// concurrent Mutate calls queue instead of failing to upgrade their lock
+	db, err := sql.Open("sqlite3", path+"?_busy_timeout=5000&_journal_mode=WAL&_txlock=immediate")
+	if err != nil {
+		return nil, fmt.Errorf("cannot open database: %w", err)
+	}
+
+	repo := &SQLiteRepository{db: db}
+	err = repo.migrate(context.Background())
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+func (repo *SQLiteRepository) migrate(ctx context.Context) error {
+	var version int
+	err := repo.db.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version)
+	if err != nil {
+		return fmt.Errorf("cannot read schema version: %w", err)
+	}
+
+	for ; version < len(migrations); version++ {
+		tx, err := repo.db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("cannot begin migration: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, migrations[version])
+		if err == nil {
+			_, err = tx.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", version+1))
+		}
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("cannot apply migration %d: %w", version+1, err)
+		}
+
+		err = tx.Commit()
+		if err != nil {
+			return fmt.Errorf("cannot commit migration %d: %w", version+1, err)
+		}
+	}
+
+	return nil
+}
+
+// Close closes the database
+func (repo *SQLiteRepository) Close() error {
+	return repo.db.Close()
+}
+
+func (repo *SQLiteRepository) Create(ctx context.Context, invoice *proto.InvoiceRecord) error {
+	data, err := protobuf.Marshal(invoice)
+	if err != nil {
+		return fmt.Errorf("cannot marshal invoice: %w", err)
+	}
+
+	return repo.inTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO invoices (id, payer_id, currency, total, status, created_at, updated_at, data)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			invoice.Id,
+			invoice.PayerId,
+			invoice.Currency,
+			invoice.Total,
+			int32(invoice.Status),
+			invoice.GetCreatedAt().AsTime().UnixNano(),
+			invoice.GetUpdatedAt().AsTime().UnixNano(),
+			data,
+		)
+		if isConstraintError(err) {
+			return ErrAlreadyExists
+		}
+		if err != nil {
+			return fmt.Errorf("cannot insert invoice: %w", err)
+		}
+
+		return writeMetadata(ctx, tx, invoice)
+	})
+}
+
+func (repo *SQLiteRepository) Get(ctx context.Context, id string) (*proto.InvoiceRecord, error) {
+	row := repo.db.QueryRowContext(ctx, `SELECT data FROM invoices WHERE id = ?`, id)
+	return scanInvoice(row)
+}
+
+func (repo *SQLiteRepository) Update(ctx context.Context, invoice *proto.InvoiceRecord) error {
+	return repo.inTx(ctx, func(tx *sql.Tx) error {
+		return update(ctx, tx, invoice)
+	})
+}
+
+func (repo *SQLiteRepository) Mutate(ctx context.Context, id string, fn func(invoice *proto.InvoiceRecord) error) (*proto.InvoiceRecord, error) {
+	var invoice *proto.InvoiceRecord
+	err := repo.inTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		invoice, err = scanInvoice(tx.QueryRowContext(ctx, `SELECT data FROM invoices WHERE id = ?`, id))
+		if err != nil {
+			return err
+		}
+
+		err = fn(invoice)
+		if err != nil {
+			return err
+		}
+
+		return update(ctx, tx, invoice)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return invoice, nil
+}
+
+func (repo *SQLiteRepository) Delete(ctx context.Context, id string) (*proto.InvoiceRecord, error) {
+	var invoice *proto.InvoiceRecord
+	err := repo.inTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		invoice, err = scanInvoice(tx.QueryRowContext(ctx, `DELETE FROM invoices WHERE id = ? RETURNING data`, id))
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `DELETE FROM invoice_metadata WHERE invoice_id = ?`, id)
+		if err != nil {
+			return fmt.Errorf("cannot delete invoice metadata: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return invoice, nil
+}
+
+// inTx runs fn in a transaction, which is committed when fn succeeds
+func (repo *SQLiteRepository) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("cannot begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = fn(tx)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("cannot commit transaction: %w", err)
+	}
+	return nil
+}
+
+func update(ctx context.Context, tx *sql.Tx, invoice *proto.InvoiceRecord) error {
+	data, err := protobuf.Marshal(invoice)
+	if err != nil {
+		return fmt.Errorf("cannot marshal invoice: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx,
+		`UPDATE invoices SET payer_id = ?, currency = ?, total = ?, status = ?, updated_at = ?, data = ?
+		WHERE id = ?`,
+		invoice.PayerId,
+		invoice.Currency,
+		invoice.Total,
+		int32(invoice.Status),
+		invoice.GetUpdatedAt().AsTime().UnixNano(),
+		data,
+		invoice.Id,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot update invoice: %w", err)
+	}
+
+	err = requireOneRow(result)
+	if err != nil {
+		return err
+	}
+	return writeMetadata(ctx, tx, invoice)
+}
+
+// writeMetadata replaces the rows that index the metadata of invoice
+func writeMetadata(ctx context.Context, tx *sql.Tx, invoice *proto.InvoiceRecord) error {
+	_, err := tx.ExecContext(ctx, `DELETE FROM invoice_metadata WHERE invoice_id = ?`, invoice.Id)
+	if err != nil {
+		return fmt.Errorf("cannot delete invoice metadata: %w", err)
+	}
+
+	for key, value := range invoice.Metadata {
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO invoice_metadata (invoice_id, key, value) VALUES (?, ?, ?)`,
+			invoice.Id, key, value,
+		)
+		if err != nil {
+			return fmt.Errorf("cannot insert invoice metadata: %w", err)
+		}
+	}
+	return nil
+}
+
+func (repo *SQLiteRepository) List(ctx context.Context, query ListQuery) ([]*proto.InvoiceRecord, error) {
+	where := []string{}
+	args := []any{}
+
+	if query.PayerID != "" {
+		where = append(where, "payer_id = ?")
+		args = append(args, query.PayerID)
+	}
+	if query.Currency != "" {
+		where = append(where, "currency = ?")
+		args = append(args, query.Currency)
+	}
+	if query.Status != proto.InvoiceStatus_INVOICE_STATUS_UNSPECIFIED {
+		where = append(where, "status = ?")
+		args = append(args, int32(query.Status))
+	}
+	for key, value := range query.Metadata {
+		where = append(where, "id IN (SELECT invoice_id FROM invoice_metadata WHERE key = ? AND value = ?)")
+		args = append(args, key, value)
+	}
+	if !query.CreatedAfter.IsZero() {
+		where = append(where, "created_at >= ?")
+		args = append(args, query.CreatedAfter.UnixNano())
+	}
+	if !query.CreatedBefore.IsZero() {
+		where = append(where, "created_at < ?")
+		args = append(args, query.CreatedBefore.UnixNano())
+	}
+
+	column := "created_at"
+	if query.SortBy == proto.InvoiceSortField_INVOICE_SORT_AMOUNT {
+		column = "total"
+	}
+	operator, direction := ">", "ASC"
+	if query.Descending {
+		operator, direction = "<", "DESC"
+	}
+
+	if query.After != nil {
+		var key any = query.After.CreatedAt
+		if column == "total" {
+			key = query.After.Total
+		}
+		where = append(where, fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))", column, operator))
+		args = append(args, key, key, query.After.ID)
+	}
+
+	statement := "SELECT data FROM invoices"
+	if len(where) > 0 {
+		statement += " WHERE " + strings.Join(where, " AND ")
+	}
+	statement += fmt.Sprintf(" ORDER BY %[1]s %[2]s, id %[2]s", column, direction)
+	if query.Limit > 0 {
+		statement += " LIMIT ?"
+		args = append(args, query.Limit)
+	}
+
+	rows, err := repo.db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list invoices: %w", err)
+	}
+	defer rows.Close()
+
+	invoices := []*proto.InvoiceRecord{}
+	for rows.Next() {
+		invoice, err := scanInvoice(rows)
+		if err != nil {
+			return nil, err
+		}
+		invoices = append(invoices, invoice)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("cannot list invoices: %w", err)
+	}
+	return invoices, nil
+}
+
+// scanner is implemented by *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanInvoice(row scanner) (*proto.InvoiceRecord, error) {
+	var data []byte
+	err := row.Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read invoice: %w", err)
+	}
+
+	invoice := &proto.InvoiceRecord{}
+	err = protobuf.Unmarshal(data, invoice)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal invoice: %w", err)
+	}
+	return invoice, nil
+}
+
+func requireOneRow(result sql.Result) error {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("cannot count affected rows: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func isConstraintError(err error) bool {
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.Code == sqlite3.ErrConstraint
+}
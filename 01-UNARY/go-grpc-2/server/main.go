@@ -1,8 +1,10 @@
 package main
 
 import (
-	"context"
+	"flag"
+	"grpc-2/events"
 	proto "grpc-2/invoice"
+	"grpc-2/repository"
 	"log"
 	"net"
 
@@ -10,19 +12,26 @@ import (
 	"google.golang.org/grpc/reflection"
 )
 
-type Server struct {
-	proto.UnimplementedInvoiceServer
-}
-
 func main() {
-	println("Server starting at :50051...")
-	listener, err := net.Listen("tcp", ":50051")
+	address := flag.String("address", ":50051", "address to listen on")
+	dbPath := flag.String("db", "", "SQLite database file, invoices are kept in memory when empty")
+	watchHistory := flag.Int("watch-history", events.DefaultHistorySize, "number of invoice events kept for watchers to resume from")
+	watchBuffer := flag.Int("watch-buffer", events.DefaultBufferSize, "number of invoice events a watcher may fall behind before it is disconnected")
+	flag.Parse()
+
+	repo, err := newRepository(*dbPath)
+	if err != nil {
+		log.Fatalf("cannot create invoice repository: %v", err)
+	}
+
+	println("Server starting at " + *address + "...")
+	listener, err := net.Listen("tcp", *address)
 	if err != nil {
 		panic(err)
 	}
 
 	serv := grpc.NewServer()
-	proto.RegisterInvoiceServer(serv, &Server{})
+	proto.RegisterInvoiceServer(serv, NewServer(repo, events.NewBroker(*watchHistory, *watchBuffer)))
 	reflection.Register(serv)
 
 	if err := serv.Serve(listener); err != nil {
@@ -30,49 +39,12 @@ func main() {
 	}
 }
 
-func (s *Server) GetInvoice(ctx context.Context, req *proto.InvoiceRequest) (*proto.InvoiceResponse, error) {
-	log.Println("new request \"GetInvoice\"")
-	res := &proto.InvoiceResponse{
-		Id:         req.Id,
-		PayerId:    "test",
-		PayerName:  "req.PayerName",
-		PayerEmail: "req.PayerEmail",
+func newRepository(dbPath string) (repository.InvoiceRepository, error) {
+	if dbPath == "" {
+		log.Println("storing invoices in memory")
+		return repository.NewMemoryRepository(), nil
 	}
-	return res, nil
-}
 
-func (s *Server) CreateInvoice(ctx context.Context, req *proto.CreateInvoiceRequest) (*proto.CreateInvoiceResponse, error) {
-	log.Println("new request \"CreateInvoice\"")
-	res := &proto.CreateInvoiceResponse{
-		InvMessage: "test",
-		Id:         req.Id,
-		PayerId:    req.PayerId,
-		PayerName:  req.PayerName,
-		PayerEmail: req.PayerEmail,
-	}
-	return res, nil
-}
-
-func (s * Server) UpdateInvoice(ctx context.Context, req *proto.UpdateInvoiceRequest) (*proto.UpdateInvoiceResponse, error) {
-	log.Println("new request \"UpdateInvoice\"")
-	res := &proto.UpdateInvoiceResponse{
-		InvMessage: "test",
-		Id:         req.Id,
-		PayerId:    req.PayerId,
-		PayerName:  req.PayerName,
-		PayerEmail: req.PayerEmail,
-	}
-	return res, nil
+	log.Printf("storing invoices in %s", dbPath)
+	return repository.NewSQLiteRepository(dbPath)
 }
-
-func (s *Server) DeleteInvoice(ctx context.Context, req *proto.DeleteInvoiceRequest) (*proto.DeleteInvoiceResponse, error) {
-	log.Println("new request \"DeleteInvoice\"")
-	res := &proto.DeleteInvoiceResponse{
-		InvMessage: "test",
-		Id:         req.Id,
-		PayerId:    "dummy PayerId" ,
-		PayerName:  "dummy PayerName" ,
-		PayerEmail: "dummy PayerEmail" ,
-	}
-	return res, nil
-}
\ No newline at end of file
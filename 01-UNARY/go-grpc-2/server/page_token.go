@@ -0,0 +1,77 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	proto "grpc-2/invoice"
+	"grpc-2/repository"
+	"grpc-2/validator"
+	"sort"
+)
+
+// pageToken is what a ListInvoices page token decodes to. Clients must treat
+// the token as opaque, its encoding may change at any time.
+type pageToken struct {
+	Cursor repository.Cursor `json:"p"`
+	// Query fingerprints the filters and sort order the token was issued for
+	Query string `json:"q"`
+}
+
+// queryFingerprint summarises everything in a ListInvoicesRequest except the
+// page size and token, which may change from one page to the next
+func queryFingerprint(req *proto.ListInvoicesRequest) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%q|%q|%d|%d|%d|%d|%t",
+		validator.NormalizePayerID(req.GetPayerId()),
+		req.GetCurrency(),
+		req.GetStatus(),
+		req.GetCreatedAfter().AsTime().UnixNano(),
+		req.GetCreatedBefore().AsTime().UnixNano(),
+		req.GetSortBy(),
+		req.GetDescending(),
+	)
+
+	keys := make([]string, 0, len(req.GetMetadata()))
+	for key := range req.GetMetadata() {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(hash, "|%q=%q", key, req.GetMetadata()[key])
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)[:8])
+}
+
+func encodePageToken(cursor repository.Cursor, req *proto.ListInvoicesRequest) (string, error) {
+	data, err := json.Marshal(pageToken{
+		Cursor: cursor,
+		Query:  queryFingerprint(req),
+	})
+	if err != nil {
+		return "", fmt.Errorf("cannot encode page token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodePageToken(token string, req *proto.ListInvoicesRequest) (*repository.Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.New("malformed page token")
+	}
+
+	decoded := pageToken{}
+	err = json.Unmarshal(data, &decoded)
+	if err != nil || decoded.Cursor.ID == "" {
+		return nil, errors.New("malformed page token")
+	}
+
+	if decoded.Query != queryFingerprint(req) {
+		return nil, errors.New("page token was issued for different filters or sort order")
+	}
+	return &decoded.Cursor, nil
+}
@@ -0,0 +1,504 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"grpc-2/billing"
+	"grpc-2/events"
+	proto "grpc-2/invoice"
+	"grpc-2/money"
+	"grpc-2/render"
+	"grpc-2/repository"
+	"grpc-2/validator"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type Server struct {
+	proto.UnimplementedInvoiceServer
+	repo   repository.InvoiceRepository
+	events *events.Broker
+}
+
+func NewServer(repo repository.InvoiceRepository, broker *events.Broker) *Server {
+	return &Server{
+		repo:   repo,
+		events: broker,
+	}
+}
+
+func (s *Server) GetInvoice(ctx context.Context, req *proto.InvoiceRequest) (*proto.InvoiceResponse, error) {
+	log.Println("new request \"GetInvoice\"")
+
+	invoice, err := s.repo.Get(ctx, req.Id)
+	if err != nil {
+		return nil, repositoryError(err, "cannot get invoice %s", req.Id)
+	}
+
+	res := &proto.InvoiceResponse{
+		InvMessage: "invoice found",
+		Id:         invoice.Id,
+		PayerId:    invoice.PayerId,
+		PayerName:  invoice.PayerName,
+		PayerEmail: invoice.PayerEmail,
+		Invoice:    invoice,
+	}
+	return res, nil
+}
+
+func (s *Server) CreateInvoice(ctx context.Context, req *proto.CreateInvoiceRequest) (*proto.CreateInvoiceResponse, error) {
+	log.Println("new request \"CreateInvoice\"")
+
+	id := req.Id
+	if id == "" {
+		id = uuid.NewString()
+	}
+
+	now := timestamppb.Now()
+	invoice := &proto.InvoiceRecord{
+		Id:          id,
+		PayerId:     req.PayerId,
+		PayerName:   req.PayerName,
+		PayerEmail:  req.PayerEmail,
+		Currency:    req.Currency,
+		Description: req.Description,
+		Metadata:    req.Metadata,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Status:      proto.InvoiceStatus_INVOICE_STATUS_DRAFT,
+		LineItems:   req.LineItems,
+		Total:       req.Amount,
+	}
+
+	validator.NormalizeInvoice(invoice)
+	if badRequest := validator.Invoice(invoice); badRequest != nil {
+		return nil, invalidInvoiceError(badRequest)
+	}
+	err := computeTotals(invoice)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.events.Commit(proto.InvoiceEventType_INVOICE_EVENT_CREATED, id, func() (*proto.InvoiceRecord, error) {
+		return invoice, s.repo.Create(ctx, invoice)
+	})
+	if err != nil {
+		return nil, repositoryError(err, "cannot create invoice %s", id)
+	}
+
+	res := &proto.CreateInvoiceResponse{
+		InvMessage: "invoice created",
+		Id:         invoice.Id,
+		PayerId:    invoice.PayerId,
+		PayerName:  invoice.PayerName,
+		PayerEmail: invoice.PayerEmail,
+		Invoice:    invoice,
+	}
+	return res, nil
+}
+
+func (s *Server) UpdateInvoice(ctx context.Context, req *proto.UpdateInvoiceRequest) (*proto.UpdateInvoiceResponse, error) {
+	log.Println("new request \"UpdateInvoice\"")
+
+	invoice, err := s.mutate(ctx, req.Id, proto.InvoiceEventType_INVOICE_EVENT_UPDATED, func(invoice *proto.InvoiceRecord) error {
+		if invoice.Status != proto.InvoiceStatus_INVOICE_STATUS_DRAFT {
+			return errNotDraft
+		}
+
+		replaceIfSet(&invoice.PayerId, req.PayerId)
+		replaceIfSet(&invoice.PayerName, req.PayerName)
+		replaceIfSet(&invoice.PayerEmail, req.PayerEmail)
+		replaceIfSet(&invoice.Currency, req.Currency)
+		replaceIfSet(&invoice.Description, req.Description)
+		if len(req.Metadata) > 0 {
+			invoice.Metadata = req.Metadata
+		}
+		if len(req.LineItems) > 0 {
+			invoice.LineItems = req.LineItems
+			invoice.Total = req.Amount
+		} else if req.Amount != 0 {
+			invoice.Total = req.Amount
+		}
+		invoice.UpdatedAt = timestamppb.Now()
+
+		validator.NormalizeInvoice(invoice)
+		if badRequest := validator.Invoice(invoice); badRequest != nil {
+			return invalidInvoiceError(badRequest)
+		}
+		return computeTotals(invoice)
+	})
+	if err != nil {
+		return nil, repositoryError(err, "cannot update invoice %s", req.Id)
+	}
+
+	res := &proto.UpdateInvoiceResponse{
+		InvMessage: "invoice updated",
+		Id:         invoice.Id,
+		PayerId:    invoice.PayerId,
+		PayerName:  invoice.PayerName,
+		PayerEmail: invoice.PayerEmail,
+		Invoice:    invoice,
+	}
+	return res, nil
+}
+
+func (s *Server) DeleteInvoice(ctx context.Context, req *proto.DeleteInvoiceRequest) (*proto.DeleteInvoiceResponse, error) {
+	log.Println("new request \"DeleteInvoice\"")
+
+	var invoice *proto.InvoiceRecord
+	err := s.events.Commit(proto.InvoiceEventType_INVOICE_EVENT_DELETED, req.Id, func() (*proto.InvoiceRecord, error) {
+		var err error
+		invoice, err = s.repo.Delete(ctx, req.Id)
+		return invoice, err
+	})
+	if err != nil {
+		return nil, repositoryError(err, "cannot delete invoice %s", req.Id)
+	}
+
+	res := &proto.DeleteInvoiceResponse{
+		InvMessage: "invoice deleted",
+		Id:         invoice.Id,
+		PayerId:    invoice.PayerId,
+		PayerName:  invoice.PayerName,
+		PayerEmail: invoice.PayerEmail,
+		Invoice:    invoice,
+	}
+	return res, nil
+}
+
+// idempotencyKeyHeader is the metadata key a client sets to make PayInvoice retries safe
+const idempotencyKeyHeader = "idempotency-key"
+
+// replayedHeader is set on the response of a PayInvoice retry that returned the original payment
+const replayedHeader = "idempotent-replayed"
+
+// errNotDraft is returned when editing an invoice that has already been issued
+var errNotDraft = errors.New("only draft invoices can be edited")
+
+func (s *Server) PayInvoice(ctx context.Context, req *proto.PayInvoiceRequest) (*proto.PayInvoiceResponse, error) {
+	log.Println("new request \"PayInvoice\"")
+
+	idempotencyKey := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(idempotencyKeyHeader); len(values) > 0 {
+			idempotencyKey = values[0]
+		}
+	}
+
+	var payment *proto.Payment
+	var replayed bool
+	err := s.events.Commit(proto.InvoiceEventType_INVOICE_EVENT_PAID, req.Id, func() (*proto.InvoiceRecord, error) {
+		invoice, err := s.repo.Mutate(ctx, req.Id, func(invoice *proto.InvoiceRecord) error {
+			now := time.Now()
+
+			var err error
+			payment, replayed, err = billing.Pay(invoice, req.Amount, idempotencyKey, now)
+			if err != nil {
+				return err
+			}
+			if !replayed {
+				invoice.UpdatedAt = timestamppb.New(now)
+			}
+			return nil
+		})
+		if replayed {
+			// nothing changed, there is nothing to publish
+			return nil, err
+		}
+		return invoice, err
+	})
+	if err != nil {
+		return nil, repositoryError(err, "cannot pay invoice %s", req.Id)
+	}
+
+	if replayed {
+		err = grpc.SetHeader(ctx, metadata.Pairs(replayedHeader, "true"))
+		if err != nil {
+			log.Printf("cannot set header: %v", err)
+		}
+	}
+
+	// a replay reports the invoice as it was right after the original payment
+	res := &proto.PayInvoiceResponse{
+		InvoiceId:  req.Id,
+		Payment:    payment,
+		Status:     payment.StatusAfter,
+		AmountPaid: payment.AmountPaidAfter,
+	}
+	return res, nil
+}
+
+func (s *Server) ChangeInvoiceStatus(ctx context.Context, req *proto.ChangeInvoiceStatusRequest) (*proto.ChangeInvoiceStatusResponse, error) {
+	log.Println("new request \"ChangeInvoiceStatus\"")
+
+	if billing.IsPaymentStatus(req.Status) {
+		return nil, status.Errorf(codes.InvalidArgument, "status %s can only be reached by paying the invoice", req.Status)
+	}
+
+	invoice, err := s.mutate(ctx, req.Id, proto.InvoiceEventType_INVOICE_EVENT_UPDATED, func(invoice *proto.InvoiceRecord) error {
+		err := billing.Transition(invoice, req.Status)
+		if err != nil {
+			return err
+		}
+		invoice.UpdatedAt = timestamppb.Now()
+		return nil
+	})
+	if err != nil {
+		return nil, repositoryError(err, "cannot change status of invoice %s", req.Id)
+	}
+
+	res := &proto.ChangeInvoiceStatusResponse{
+		Invoice: invoice,
+	}
+	return res, nil
+}
+
+func (s *Server) WatchInvoices(req *proto.WatchInvoicesRequest, stream proto.Invoice_WatchInvoicesServer) error {
+	log.Println("new request \"WatchInvoices\"")
+
+	subscription, err := s.events.Subscribe(req.AfterSequence)
+	if errors.Is(err, events.ErrSequenceOutOfRange) {
+		return status.Errorf(codes.OutOfRange, "cannot resume: %v", err)
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "cannot subscribe: %v", err)
+	}
+	defer subscription.Close()
+
+	for {
+		event, err := subscription.Next(stream.Context())
+		if errors.Is(err, events.ErrSubscriberTooSlow) {
+			return status.Errorf(codes.ResourceExhausted, "%v", err)
+		}
+		if err != nil {
+			return contextError(stream.Context(), err)
+		}
+
+		err = stream.Send(event)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// mutate applies fn to the stored invoice and publishes an event of type
+// eventType with the result
+func (s *Server) mutate(ctx context.Context, id string, eventType proto.InvoiceEventType, fn func(invoice *proto.InvoiceRecord) error) (*proto.InvoiceRecord, error) {
+	var invoice *proto.InvoiceRecord
+	err := s.events.Commit(eventType, id, func() (*proto.InvoiceRecord, error) {
+		var err error
+		invoice, err = s.repo.Mutate(ctx, id, fn)
+		return invoice, err
+	})
+	return invoice, err
+}
+
+// renderChunkSize is the size of the chunks a rendered invoice is streamed in
+const renderChunkSize = 32 * 1024
+
+func (s *Server) RenderInvoice(req *proto.RenderInvoiceRequest, stream proto.Invoice_RenderInvoiceServer) error {
+	log.Println("new request \"RenderInvoice\"")
+
+	invoice, err := s.repo.Get(stream.Context(), req.Id)
+	if err != nil {
+		return repositoryError(err, "cannot get invoice %s", req.Id)
+	}
+
+	document, err := render.Render(invoice, req.Format)
+	if errors.Is(err, render.ErrUnknownFormat) {
+		return status.Errorf(codes.InvalidArgument, "cannot render invoice %s: %v", req.Id, err)
+	}
+	if err != nil {
+		return repositoryError(err, "cannot render invoice %s", req.Id)
+	}
+
+	sum := sha256.Sum256(document.Data)
+	err = stream.Send(&proto.RenderInvoiceResponse{
+		Data: &proto.RenderInvoiceResponse_Info{
+			Info: &proto.RenderedDocumentInfo{
+				ContentType: document.ContentType,
+				Filename:    document.Filename,
+				Size:        int64(len(document.Data)),
+				Sha256:      hex.EncodeToString(sum[:]),
+				Version:     invoice.UpdatedAt,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	for data := document.Data; len(data) > 0; {
+		n := min(len(data), renderChunkSize)
+		err = stream.Send(&proto.RenderInvoiceResponse{
+			Data: &proto.RenderInvoiceResponse_ChunkData{
+				ChunkData: data[:n],
+			},
+		})
+		if err != nil {
+			return err
+		}
+		data = data[n:]
+	}
+
+	return nil
+}
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 1000
+)
+
+func (s *Server) ListInvoices(ctx context.Context, req *proto.ListInvoicesRequest) (*proto.ListInvoicesResponse, error) {
+	log.Println("new request \"ListInvoices\"")
+
+	pageSize := int(req.PageSize)
+	switch {
+	case pageSize < 0:
+		return nil, status.Errorf(codes.InvalidArgument, "page size must not be negative, got %d", pageSize)
+	case pageSize == 0:
+		pageSize = defaultPageSize
+	case pageSize > maxPageSize:
+		pageSize = maxPageSize
+	}
+
+	if badRequest := validator.Metadata(req.Metadata); badRequest != nil {
+		return nil, invalidArgumentError(badRequest, "invalid metadata filter")
+	}
+	// totals are only comparable within one currency
+	if req.SortBy == proto.InvoiceSortField_INVOICE_SORT_AMOUNT && req.Currency == "" {
+		return nil, status.Error(codes.InvalidArgument, "sorting by amount requires a currency filter")
+	}
+
+	query := repository.ListQuery{
+		PayerID:    validator.NormalizePayerID(req.PayerId),
+		Metadata:   req.Metadata,
+		Currency:   req.Currency,
+		Status:     req.Status,
+		SortBy:     req.SortBy,
+		Descending: req.Descending,
+		// one more than the page to learn whether another page follows
+		Limit: pageSize + 1,
+	}
+	if req.CreatedAfter != nil {
+		query.CreatedAfter = req.CreatedAfter.AsTime()
+	}
+	if req.CreatedBefore != nil {
+		query.CreatedBefore = req.CreatedBefore.AsTime()
+	}
+
+	if req.PageToken != "" {
+		cursor, err := decodePageToken(req.PageToken, req)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %v", err)
+		}
+		query.After = cursor
+	}
+
+	invoices, err := s.repo.List(ctx, query)
+	if err != nil {
+		return nil, repositoryError(err, "cannot list invoices")
+	}
+
+	res := &proto.ListInvoicesResponse{
+		Invoices: invoices,
+	}
+
+	if len(invoices) > pageSize {
+		res.Invoices = invoices[:pageSize]
+		res.NextPageToken, err = encodePageToken(repository.NewCursor(invoices[pageSize-1]), req)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "cannot create page token: %v", err)
+		}
+	}
+
+	return res, nil
+}
+
+// replaceIfSet overwrites *field with value unless value is empty
+func replaceIfSet(field *string, value string) {
+	if value != "" {
+		*field = value
+	}
+}
+
+// computeTotals computes the totals of a validated invoice, reporting a total
+// that does not add up as a field violation
+func computeTotals(invoice *proto.InvoiceRecord) error {
+	err := billing.ComputeTotals(invoice)
+	if err == nil {
+		return nil
+	}
+
+	field := "amount"
+	if len(invoice.LineItems) > 0 {
+		field = "lineItems"
+	}
+	return invalidInvoiceError(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: field, Description: err.Error()},
+		},
+	})
+}
+
+// invalidInvoiceError builds an InvalidArgument status carrying the field violations
+func invalidInvoiceError(badRequest *errdetails.BadRequest) error {
+	return invalidArgumentError(badRequest, "invalid invoice")
+}
+
+func invalidArgumentError(badRequest *errdetails.BadRequest, message string) error {
+	st := status.Newf(codes.InvalidArgument, "%s: %d field violation(s)", message, len(badRequest.GetFieldViolations()))
+	detailed, err := st.WithDetails(badRequest)
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// contextError maps a finished context to the matching gRPC status error,
+// and any other error to Internal
+func contextError(ctx context.Context, err error) error {
+	switch ctx.Err() {
+	case context.Canceled:
+		return status.Error(codes.Canceled, "request is canceled")
+	case context.DeadlineExceeded:
+		return status.Error(codes.DeadlineExceeded, "deadline is exceeded")
+	}
+	return status.Errorf(codes.Internal, "%v", err)
+}
+
+// repositoryError maps a repository or billing error to a gRPC status error.
+// Errors that already are a gRPC status are returned unchanged.
+func repositoryError(err error, format string, args ...any) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	code := codes.Internal
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		code = codes.NotFound
+	case errors.Is(err, repository.ErrAlreadyExists):
+		code = codes.AlreadyExists
+	case errors.Is(err, money.ErrInvalidAmount),
+		errors.Is(err, billing.ErrIdempotencyKeyReused):
+		code = codes.InvalidArgument
+	case errors.Is(err, billing.ErrInvalidTransition),
+		errors.Is(err, billing.ErrOverpayment),
+		errors.Is(err, errNotDraft):
+		code = codes.FailedPrecondition
+	}
+
+	log.Printf(format+": %v", append(args, err)...)
+	return status.Errorf(code, format+": %v", append(args, err)...)
+}
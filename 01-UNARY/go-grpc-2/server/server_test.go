@@ -0,0 +1,389 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"grpc-2/events"
+	proto "grpc-2/invoice"
+	"grpc-2/repository"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+const bufSize = 1 << 20
+
+func TestInvoiceCRUD(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, events.NewBroker(events.DefaultHistorySize, events.DefaultBufferSize))
+	ctx := context.Background()
+
+	created, err := client.CreateInvoice(ctx, newCreateRequest("inv-1"))
+	require.NoError(t, err)
+	require.Equal(t, "inv-1", created.Id)
+	require.Equal(t, proto.InvoiceStatus_INVOICE_STATUS_DRAFT, created.Invoice.Status)
+	require.Equal(t, int64(1230), created.Invoice.Total)
+
+	_, err = client.CreateInvoice(ctx, newCreateRequest("inv-1"))
+	requireCode(t, codes.AlreadyExists, err)
+
+	invalid := newCreateRequest("inv-2")
+	invalid.PayerEmail = "not an email"
+	_, err = client.CreateInvoice(ctx, invalid)
+	requireCode(t, codes.InvalidArgument, err)
+
+	found, err := client.GetInvoice(ctx, &proto.InvoiceRequest{Id: "inv-1"})
+	require.NoError(t, err)
+	require.Equal(t, "payer-1", found.PayerId)
+
+	_, err = client.GetInvoice(ctx, &proto.InvoiceRequest{Id: "unknown"})
+	requireCode(t, codes.NotFound, err)
+
+	updated, err := client.UpdateInvoice(ctx, &proto.UpdateInvoiceRequest{Id: "inv-1", Amount: 4560})
+	require.NoError(t, err)
+	require.Equal(t, int64(4560), updated.Invoice.Total)
+
+	_, err = client.UpdateInvoice(ctx, &proto.UpdateInvoiceRequest{Id: "unknown", Amount: 4560})
+	requireCode(t, codes.NotFound, err)
+
+	// only draft invoices can be edited
+	_, err = client.ChangeInvoiceStatus(ctx, &proto.ChangeInvoiceStatusRequest{
+		Id:     "inv-1",
+		Status: proto.InvoiceStatus_INVOICE_STATUS_ISSUED,
+	})
+	require.NoError(t, err)
+	_, err = client.UpdateInvoice(ctx, &proto.UpdateInvoiceRequest{Id: "inv-1", Amount: 7890})
+	requireCode(t, codes.FailedPrecondition, err)
+
+	_, err = client.ChangeInvoiceStatus(ctx, &proto.ChangeInvoiceStatusRequest{
+		Id:     "inv-1",
+		Status: proto.InvoiceStatus_INVOICE_STATUS_PAID,
+	})
+	requireCode(t, codes.InvalidArgument, err)
+
+	deleted, err := client.DeleteInvoice(ctx, &proto.DeleteInvoiceRequest{Id: "inv-1"})
+	require.NoError(t, err)
+	require.Equal(t, "inv-1", deleted.Id)
+
+	_, err = client.DeleteInvoice(ctx, &proto.DeleteInvoiceRequest{Id: "inv-1"})
+	requireCode(t, codes.NotFound, err)
+	_, err = client.GetInvoice(ctx, &proto.InvoiceRequest{Id: "inv-1"})
+	requireCode(t, codes.NotFound, err)
+}
+
+func TestListInvoices(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, events.NewBroker(events.DefaultHistorySize, events.DefaultBufferSize))
+	ctx := context.Background()
+
+	for i, currency := range []string{"EUR", "EUR", "USD", "EUR", "EUR"} {
+		req := newCreateRequest(fmt.Sprintf("inv-%d", i))
+		req.Currency = currency
+		req.Amount = int64(1000 * (5 - i))
+		_, err := client.CreateInvoice(ctx, req)
+		require.NoError(t, err)
+	}
+
+	req := &proto.ListInvoicesRequest{
+		PageSize: 2,
+		Currency: "EUR",
+		SortBy:   proto.InvoiceSortField_INVOICE_SORT_AMOUNT,
+	}
+	seen := []string{}
+	for {
+		res, err := client.ListInvoices(ctx, req)
+		require.NoError(t, err)
+		for _, invoice := range res.Invoices {
+			seen = append(seen, invoice.Id)
+		}
+		if res.NextPageToken == "" {
+			break
+		}
+		req.PageToken = res.NextPageToken
+	}
+	require.Equal(t, []string{"inv-4", "inv-3", "inv-1", "inv-0"}, seen)
+
+	first, err := client.ListInvoices(ctx, &proto.ListInvoicesRequest{PageSize: 2, Currency: "EUR"})
+	require.NoError(t, err)
+	require.NotEmpty(t, first.NextPageToken)
+
+	testCases := []struct {
+		name string
+		req  *proto.ListInvoicesRequest
+	}{
+		{
+			name: "token_with_other_filter",
+			req:  &proto.ListInvoicesRequest{PageSize: 2, Currency: "USD", PageToken: first.NextPageToken},
+		},
+		{
+			name: "token_with_other_sort",
+			req:  &proto.ListInvoicesRequest{PageSize: 2, Currency: "EUR", Descending: true, PageToken: first.NextPageToken},
+		},
+		{
+			name: "malformed_token",
+			req:  &proto.ListInvoicesRequest{PageToken: "not a token"},
+		},
+		{
+			name: "negative_page_size",
+			req:  &proto.ListInvoicesRequest{PageSize: -1},
+		},
+		{
+			name: "amount_without_currency",
+			req:  &proto.ListInvoicesRequest{SortBy: proto.InvoiceSortField_INVOICE_SORT_AMOUNT},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := client.ListInvoices(ctx, tc.req)
+			requireCode(t, codes.InvalidArgument, err)
+		})
+	}
+}
+
+func TestPayInvoiceIdempotency(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, events.NewBroker(events.DefaultHistorySize, events.DefaultBufferSize))
+	ctx := context.Background()
+
+	_, err := client.CreateInvoice(ctx, newCreateRequest("inv-1"))
+	require.NoError(t, err)
+	_, err = client.ChangeInvoiceStatus(ctx, &proto.ChangeInvoiceStatusRequest{
+		Id:     "inv-1",
+		Status: proto.InvoiceStatus_INVOICE_STATUS_ISSUED,
+	})
+	require.NoError(t, err)
+
+	keyed := metadata.AppendToOutgoingContext(ctx, idempotencyKeyHeader, "key-1")
+	var header metadata.MD
+	original, err := client.PayInvoice(keyed, &proto.PayInvoiceRequest{Id: "inv-1", Amount: 500}, grpc.Header(&header))
+	require.NoError(t, err)
+	require.Empty(t, header.Get(replayedHeader))
+	require.Equal(t, proto.InvoiceStatus_INVOICE_STATUS_PARTIALLY_PAID, original.Status)
+	require.Equal(t, int64(500), original.AmountPaid)
+
+	// a payment made with another key moves the invoice on
+	_, err = client.PayInvoice(ctx, &proto.PayInvoiceRequest{Id: "inv-1", Amount: 300})
+	require.NoError(t, err)
+
+	// the retry returns the original payment and does not pay again
+	header = nil
+	replayed, err := client.PayInvoice(keyed, &proto.PayInvoiceRequest{Id: "inv-1", Amount: 500}, grpc.Header(&header))
+	require.NoError(t, err)
+	require.Equal(t, []string{"true"}, header.Get(replayedHeader))
+	require.True(t, protobuf.Equal(original, replayed), "expected %v, got %v", original, replayed)
+
+	_, err = client.PayInvoice(keyed, &proto.PayInvoiceRequest{Id: "inv-1", Amount: 600})
+	requireCode(t, codes.InvalidArgument, err)
+
+	found, err := client.GetInvoice(ctx, &proto.InvoiceRequest{Id: "inv-1"})
+	require.NoError(t, err)
+	require.Len(t, found.Invoice.Payments, 2)
+	require.Equal(t, int64(800), found.Invoice.AmountPaid)
+
+	_, err = client.PayInvoice(ctx, &proto.PayInvoiceRequest{Id: "inv-1", Amount: 1000})
+	requireCode(t, codes.FailedPrecondition, err)
+}
+
+func TestRenderInvoice(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, events.NewBroker(events.DefaultHistorySize, events.DefaultBufferSize))
+	ctx := context.Background()
+
+	// enough line items for the document to take several chunks
+	req := newCreateRequest("inv-1")
+	req.Amount = 0
+	for i := 0; i < 500; i++ {
+		req.LineItems = append(req.LineItems, &proto.LineItem{
+			Description: fmt.Sprintf("consulting, week %d", i+1),
+			Quantity:    int64(i%5 + 1),
+			UnitPrice:   12345,
+			TaxRate:     2000,
+		})
+	}
+	_, err := client.CreateInvoice(ctx, req)
+	require.NoError(t, err)
+
+	for _, format := range []proto.RenderFormat{proto.RenderFormat_RENDER_FORMAT_PDF, proto.RenderFormat_RENDER_FORMAT_HTML} {
+		t.Run(format.String(), func(t *testing.T) {
+			stream, err := client.RenderInvoice(ctx, &proto.RenderInvoiceRequest{Id: "inv-1", Format: format})
+			require.NoError(t, err)
+
+			res, err := stream.Recv()
+			require.NoError(t, err)
+			info := res.GetInfo()
+			require.NotNil(t, info)
+
+			document := []byte{}
+			chunks := 0
+			for {
+				res, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
+				require.Nil(t, res.GetInfo())
+				require.LessOrEqual(t, len(res.GetChunkData()), renderChunkSize)
+				document = append(document, res.GetChunkData()...)
+				chunks++
+			}
+
+			require.Greater(t, chunks, 1)
+			require.EqualValues(t, info.Size, len(document))
+			sum := sha256.Sum256(document)
+			require.Equal(t, info.Sha256, hex.EncodeToString(sum[:]))
+		})
+	}
+
+	testCases := []struct {
+		name string
+		req  *proto.RenderInvoiceRequest
+		code codes.Code
+	}{
+		{
+			name: "unknown_invoice",
+			req:  &proto.RenderInvoiceRequest{Id: "unknown", Format: proto.RenderFormat_RENDER_FORMAT_PDF},
+			code: codes.NotFound,
+		},
+		{
+			name: "unknown_format",
+			req:  &proto.RenderInvoiceRequest{Id: "inv-1"},
+			code: codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stream, err := client.RenderInvoice(ctx, tc.req)
+			require.NoError(t, err)
+			_, err = stream.Recv()
+			requireCode(t, tc.code, err)
+		})
+	}
+}
+
+func TestWatchInvoices(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, events.NewBroker(3, 2))
+	ctx := context.Background()
+
+	for i := 1; i <= 5; i++ {
+		_, err := client.CreateInvoice(ctx, newCreateRequest(fmt.Sprintf("inv-%d", i)))
+		require.NoError(t, err)
+	}
+
+	// sequences 3 to 5 are retained, so a watcher can resume after 2 to 5
+	resumed, err := client.WatchInvoices(ctx, &proto.WatchInvoicesRequest{AfterSequence: 3})
+	require.NoError(t, err)
+	for sequence := uint64(4); sequence <= 5; sequence++ {
+		event, err := resumed.Recv()
+		require.NoError(t, err)
+		require.Equal(t, sequence, event.Sequence)
+		require.Equal(t, proto.InvoiceEventType_INVOICE_EVENT_CREATED, event.Type)
+		require.Equal(t, fmt.Sprintf("inv-%d", sequence), event.Invoice.Id)
+	}
+
+	for _, after := range []uint64{1, 6} {
+		stream, err := client.WatchInvoices(ctx, &proto.WatchInvoicesRequest{AfterSequence: after})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		requireCode(t, codes.OutOfRange, err)
+	}
+
+	// a watcher that stops reading is disconnected once the server cannot
+	// send and its buffer is full, after the events it was sent
+	slow, err := client.WatchInvoices(ctx, &proto.WatchInvoicesRequest{AfterSequence: 5})
+	require.NoError(t, err)
+	for i := 6; i <= 200; i++ {
+		req := newCreateRequest(fmt.Sprintf("inv-%d", i))
+		req.Description = strings.Repeat("x", 4096)
+		_, err := client.CreateInvoice(ctx, req)
+		require.NoError(t, err)
+	}
+
+	last := uint64(5)
+	for {
+		event, err := slow.Recv()
+		if err != nil {
+			requireCode(t, codes.ResourceExhausted, err)
+			break
+		}
+		require.Equal(t, last+1, event.Sequence)
+		last = event.Sequence
+	}
+	require.Less(t, last, uint64(200))
+}
+
+// newCreateRequest returns a valid request for a draft invoice of 12.30 EUR
+func newCreateRequest(id string) *proto.CreateInvoiceRequest {
+	return &proto.CreateInvoiceRequest{
+		Id:         id,
+		PayerId:    "payer-1",
+		PayerName:  "Jane Doe",
+		PayerEmail: "jane@example.com",
+		Currency:   "EUR",
+		Amount:     1230,
+	}
+}
+
+// newTestClient serves the Invoice service with an in-memory repository over
+// an in-process bufconn listener. The connection is closed and the server
+// stopped when the test finishes.
+func newTestClient(t *testing.T, broker *events.Broker) proto.InvoiceClient {
+	serv := grpc.NewServer()
+	proto.RegisterInvoiceServer(serv, NewServer(repository.NewMemoryRepository(), broker))
+
+	listener := bufconn.Listen(bufSize)
+	served := make(chan error, 1)
+	go func() {
+		served <- serv.Serve(listener)
+	}()
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		// a fixed flow control window, so that a watcher that stops reading
+		// holds up the server instead of the window growing
+		grpc.WithInitialWindowSize(1<<16),
+		grpc.WithInitialConnWindowSize(1<<16),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		conn.Close()
+		serv.Stop()
+		require.NoError(t, <-served)
+	})
+
+	return proto.NewInvoiceClient(conn)
+}
+
+// requireCode asserts that err is a gRPC status error with the given code
+func requireCode(t *testing.T, code codes.Code, err error) {
+	t.Helper()
+
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok, "not a status error: %v", err)
+	require.Equal(t, code, st.Code(), st.Message())
+}
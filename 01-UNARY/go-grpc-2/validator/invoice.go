@@ -0,0 +1,176 @@
+package validator
+
+import (
+	"fmt"
+	"grpc-2/billing"
+	proto "grpc-2/invoice"
+	"grpc-2/money"
+	"net/mail"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+const (
+	maxPayerIDLength       = 64
+	maxPayerNameLength     = 200
+	maxEmailLength         = 254
+	maxMetadataPairs       = 50
+	maxMetadataKeyLength   = 40
+	maxMetadataValueLength = 500
+)
+
+var metadataKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// violations collects the field violations found so far
+type violations struct {
+	list []*errdetails.BadRequest_FieldViolation
+}
+
+func (v *violations) add(field string, format string, args ...any) {
+	v.list = append(v.list, &errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Description: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *violations) badRequest() *errdetails.BadRequest {
+	if len(v.list) == 0 {
+		return nil
+	}
+	return &errdetails.BadRequest{FieldViolations: v.list}
+}
+
+// NormalizePayerID trims the payer id and lowercases it, so "ACME-1 " and
+// "acme-1" are the same payer
+func NormalizePayerID(id string) string {
+	return strings.ToLower(strings.TrimSpace(id))
+}
+
+// NormalizePayerName trims the payer name and collapses runs of whitespace
+func NormalizePayerName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// NormalizeEmail trims the address and lowercases its domain. The local part
+// is kept as is, mail servers are free to treat it as case sensitive.
+func NormalizeEmail(email string) string {
+	email = strings.TrimSpace(email)
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return email
+	}
+	return email[:at] + strings.ToLower(email[at:])
+}
+
+// NormalizeInvoice normalises the payer fields of invoice in place
+func NormalizeInvoice(invoice *proto.InvoiceRecord) {
+	invoice.PayerId = NormalizePayerID(invoice.PayerId)
+	invoice.PayerName = NormalizePayerName(invoice.PayerName)
+	invoice.PayerEmail = NormalizeEmail(invoice.PayerEmail)
+}
+
+// Invoice checks the fields of a normalised invoice. It returns nil when the
+// invoice is valid, otherwise a BadRequest with one violation per broken rule,
+// each addressed by a field path such as "lineItems[0].quantity".
+func Invoice(invoice *proto.InvoiceRecord) *errdetails.BadRequest {
+	v := &violations{}
+
+	switch {
+	case invoice.GetPayerId() == "":
+		v.add("payerId", "is required")
+	case utf8.RuneCountInString(invoice.GetPayerId()) > maxPayerIDLength:
+		v.add("payerId", "must be at most %d characters", maxPayerIDLength)
+	}
+
+	if utf8.RuneCountInString(invoice.GetPayerName()) > maxPayerNameLength {
+		v.add("payerName", "must be at most %d characters", maxPayerNameLength)
+	}
+
+	email(v, "payerEmail", invoice.GetPayerEmail())
+
+	if _, err := money.Lookup(invoice.GetCurrency()); err != nil {
+		v.add("currency", "must be an ISO-4217 currency code such as \"EUR\", got %q", invoice.GetCurrency())
+	}
+
+	if invoice.GetTotal() < 0 {
+		v.add("amount", "must not be negative, got %d", invoice.GetTotal())
+	} else if len(invoice.GetLineItems()) == 0 && invoice.GetTotal() == 0 {
+		v.add("amount", "is required for an invoice without line items")
+	}
+	for i, item := range invoice.GetLineItems() {
+		lineItem(v, fmt.Sprintf("lineItems[%d]", i), item)
+	}
+
+	metadata(v, "metadata", invoice.GetMetadata())
+
+	return v.badRequest()
+}
+
+// Metadata checks the pairs of a metadata filter
+func Metadata(pairs map[string]string) *errdetails.BadRequest {
+	v := &violations{}
+	metadata(v, "metadata", pairs)
+	return v.badRequest()
+}
+
+func email(v *violations, path string, email string) {
+	if email == "" {
+		v.add(path, "is required")
+		return
+	}
+	if len(email) > maxEmailLength {
+		v.add(path, "must be at most %d characters", maxEmailLength)
+		return
+	}
+
+	// ParseAddress also accepts "Name <address>", only a bare address is valid here
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Name != "" || address.Address != email {
+		v.add(path, "must be an email address such as \"jane@example.com\", got %q", email)
+		return
+	}
+
+	domain := email[strings.LastIndex(email, "@")+1:]
+	if !strings.Contains(domain, ".") || strings.HasPrefix(domain, ".") || strings.HasSuffix(domain, ".") {
+		v.add(path, "must have a fully qualified domain, got %q", domain)
+	}
+}
+
+func lineItem(v *violations, path string, item *proto.LineItem) {
+	if item == nil {
+		v.add(path, "is required")
+		return
+	}
+
+	for _, violation := range billing.CheckLineItem(item) {
+		v.add(path+"."+violation.Field, "%s", violation.Description)
+	}
+}
+
+func metadata(v *violations, path string, pairs map[string]string) {
+	if len(pairs) > maxMetadataPairs {
+		v.add(path, "must have at most %d pairs, got %d", maxMetadataPairs, len(pairs))
+	}
+
+	// sorted, so the violations come in the same order every time
+	keys := make([]string, 0, len(pairs))
+	for key := range pairs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := pairs[key]
+		keyPath := fmt.Sprintf("%s[%q]", path, key)
+		if len(key) > maxMetadataKeyLength || !metadataKeyPattern.MatchString(key) {
+			v.add(keyPath, "key must be 1 to %d letters, digits, '_', '-' or '.'", maxMetadataKeyLength)
+		}
+		if utf8.RuneCountInString(value) > maxMetadataValueLength {
+			v.add(keyPath, "value must be at most %d characters", maxMetadataValueLength)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package validator_test
+
+import (
+	"fmt"
+	proto "grpc-2/invoice"
+	"grpc-2/validator"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newInvoice() *proto.InvoiceRecord {
+	return &proto.InvoiceRecord{
+		PayerId:    "payer-1",
+		PayerName:  "Jane Doe",
+		PayerEmail: "jane@example.com",
+		Currency:   "EUR",
+		LineItems: []*proto.LineItem{
+			{Description: "consulting", Quantity: 2, UnitPrice: 5000, TaxRate: 2000},
+		},
+		Metadata: map[string]string{"project": "apollo"},
+	}
+}
+
+func TestInvoice(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		modify func(invoice *proto.InvoiceRecord)
+		fields []string
+	}{
+		{
+			name:   "valid",
+			modify: func(invoice *proto.InvoiceRecord) {},
+		},
+		{
+			name: "amount_without_line_items",
+			modify: func(invoice *proto.InvoiceRecord) {
+				invoice.LineItems = nil
+				invoice.Total = 1000
+			},
+		},
+		{
+			name: "missing_payer",
+			modify: func(invoice *proto.InvoiceRecord) {
+				invoice.PayerId = ""
+				invoice.PayerEmail = ""
+			},
+			fields: []string{"payerId", "payerEmail"},
+		},
+		{
+			name: "email_with_display_name",
+			modify: func(invoice *proto.InvoiceRecord) {
+				invoice.PayerEmail = "Jane <jane@example.com>"
+			},
+			fields: []string{"payerEmail"},
+		},
+		{
+			name: "email_without_domain",
+			modify: func(invoice *proto.InvoiceRecord) {
+				invoice.PayerEmail = "jane@localhost"
+			},
+			fields: []string{"payerEmail"},
+		},
+		{
+			name: "unknown_currency",
+			modify: func(invoice *proto.InvoiceRecord) {
+				invoice.Currency = "test"
+			},
+			fields: []string{"currency"},
+		},
+		{
+			name: "missing_amount",
+			modify: func(invoice *proto.InvoiceRecord) {
+				invoice.LineItems = nil
+			},
+			fields: []string{"amount"},
+		},
+		{
+			name: "invalid_line_items",
+			modify: func(invoice *proto.InvoiceRecord) {
+				invoice.LineItems = append(invoice.LineItems, &proto.LineItem{
+					Quantity:  0,
+					UnitPrice: -1,
+					TaxRate:   10001,
+				})
+			},
+			fields: []string{"lineItems[1].quantity", "lineItems[1].unitPrice", "lineItems[1].taxRate"},
+		},
+		{
+			name: "invalid_metadata",
+			modify: func(invoice *proto.InvoiceRecord) {
+				invoice.Metadata["bad key"] = "value"
+				invoice.Metadata["long"] = strings.Repeat("x", 501)
+			},
+			fields: []string{`metadata["bad key"]`, `metadata["long"]`},
+		},
+		{
+			name: "too_much_metadata",
+			modify: func(invoice *proto.InvoiceRecord) {
+				for i := 0; i < 50; i++ {
+					invoice.Metadata[fmt.Sprintf("key-%d", i)] = "value"
+				}
+			},
+			fields: []string{"metadata"},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			invoice := newInvoice()
+			tc.modify(invoice)
+
+			badRequest := validator.Invoice(invoice)
+			if len(tc.fields) == 0 {
+				require.Nil(t, badRequest)
+				return
+			}
+
+			require.NotNil(t, badRequest)
+			fields := []string{}
+			for _, violation := range badRequest.GetFieldViolations() {
+				require.NotEmpty(t, violation.GetDescription())
+				fields = append(fields, violation.GetField())
+			}
+			require.ElementsMatch(t, tc.fields, fields)
+		})
+	}
+}
+
+func TestNormalizeInvoice(t *testing.T) {
+	t.Parallel()
+
+	invoice := &proto.InvoiceRecord{
+		PayerId:    "  ACME-1 ",
+		PayerName:  " Jane \t  Doe ",
+		PayerEmail: " Jane.Doe@Example.COM ",
+	}
+	validator.NormalizeInvoice(invoice)
+
+	require.Equal(t, "acme-1", invoice.PayerId)
+	require.Equal(t, "Jane Doe", invoice.PayerName)
+	require.Equal(t, "Jane.Doe@example.com", invoice.PayerEmail)
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"grpc-3/pb"
+	"grpc-3/serializer"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+func runExport(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	address := addServerFlag(flags)
+	out := flags.String("out", "", "output .ndjson file, or directory for one file per laptop")
+	formatName := flags.String("format", "json", "format of each file when exporting to a directory: binary, json, text or yaml")
+	timeout := flags.Duration("timeout", time.Minute, "timeout of the whole export")
+	flags.Parse(args)
+
+	if *out == "" {
+		return errors.New("export needs -out")
+	}
+
+	conn, err := dial(*address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	laptopClient := pb.NewLaptopServiceClient(conn)
+	laptops, err := searchAllLaptops(laptopClient, *timeout)
+	if err != nil {
+		return err
+	}
+
+	if format, err := serializer.FormatFromFilename(*out); err == nil && format == serializer.FormatNDJSON {
+		err = serializer.WriteProtobuffsToNDJSONFile(laptops, *out)
+		if err != nil {
+			return err
+		}
+		log.Printf("summary: %d laptops exported to %s", len(laptops), *out)
+		return nil
+	}
+
+	format, err := serializer.ParseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+	if format == serializer.FormatNDJSON {
+		return errors.New("use an .ndjson -out file to export ndjson")
+	}
+
+	err = os.MkdirAll(*out, 0755)
+	if err != nil {
+		return fmt.Errorf("cannot create output directory: %w", err)
+	}
+
+	for _, laptop := range laptops {
+		filename := filepath.Join(*out, laptop.GetId()+format.Extension())
+		err = serializer.WriteProtobuffToFile(laptop, filename)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Printf("summary: %d laptops exported to %s", len(laptops), *out)
+	return nil
+}
+
+// searchAllLaptops streams every laptop of the store with an empty filter
+func searchAllLaptops(laptopClient pb.LaptopServiceClient, timeout time.Duration) ([]*pb.Laptop, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	stream, err := laptopClient.SearchLaptop(ctx, &pb.SearchLaptopRequest{Filter: &pb.Filter{}})
+	if err != nil {
+		return nil, fmt.Errorf("cannot search laptops: %w", err)
+	}
+
+	laptops := []*pb.Laptop{}
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return laptops, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot receive laptop: %w", err)
+		}
+		laptops = append(laptops, res.GetLaptop())
+	}
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"grpc-3/pb"
+	"grpc-3/serializer"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// record is one laptop read from an input file
+type record struct {
+	source string
+	laptop *pb.Laptop
+}
+
+// failure is a record that the server rejected
+type failure struct {
+	source string
+	id     string
+	code   codes.Code
+	err    error
+}
+
+func runImport(args []string) error {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	address := addServerFlag(flags)
+	workers := flags.Int("workers", 4, "number of concurrent CreateLaptop calls")
+	timeout := flags.Duration("timeout", 5*time.Second, "timeout of each CreateLaptop call")
+	flags.Parse(args)
+
+	if flags.NArg() == 0 {
+		return errors.New("import needs at least one file or directory")
+	}
+	if *workers < 1 {
+		return errors.New("workers must be at least 1")
+	}
+
+	records, readFailures := readRecords(flags.Args())
+
+	conn, err := dial(*address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	laptopClient := pb.NewLaptopServiceClient(conn)
+	failures := createLaptops(laptopClient, records, *workers, *timeout)
+
+	printImportSummary(len(records), readFailures, failures)
+	if len(failures) > 0 || len(readFailures) > 0 {
+		return fmt.Errorf("import is incomplete: %d rejected, %d unreadable inputs", len(failures), len(readFailures))
+	}
+	return nil
+}
+
+// readRecords loads every laptop from the given files and directories. Files
+// that cannot be read are reported as failures rather than stopping the run.
+func readRecords(paths []string) ([]record, []failure) {
+	records := []record{}
+	failures := []failure{}
+
+	for _, filename := range expandPaths(paths, &failures) {
+		laptops, err := readLaptops(filename)
+		if err != nil {
+			failures = append(failures, failure{source: filename, code: codes.InvalidArgument, err: err})
+			continue
+		}
+
+		for i, laptop := range laptops {
+			source := filename
+			if len(laptops) > 1 {
+				source = fmt.Sprintf("%s:%d", filename, i+1)
+			}
+			records = append(records, record{source: source, laptop: laptop})
+		}
+	}
+
+	return records, failures
+}
+
+// expandPaths replaces each directory by the files in it with a known format
+func expandPaths(paths []string, failures *[]failure) []string {
+	filenames := []string{}
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			*failures = append(*failures, failure{source: path, code: codes.NotFound, err: err})
+			continue
+		}
+
+		if !info.IsDir() {
+			filenames = append(filenames, path)
+			continue
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			*failures = append(*failures, failure{source: path, code: codes.Unknown, err: err})
+			continue
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			if _, err := serializer.FormatFromFilename(entry.Name()); err == nil {
+				filenames = append(filenames, filepath.Join(path, entry.Name()))
+			}
+		}
+	}
+
+	return filenames
+}
+
+func readLaptops(filename string) ([]*pb.Laptop, error) {
+	format, err := serializer.FormatFromFilename(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	if format == serializer.FormatNDJSON {
+		return serializer.ReadProtobuffsFromNDJSONFile(filename, func() *pb.Laptop {
+			return &pb.Laptop{}
+		})
+	}
+
+	laptop := &pb.Laptop{}
+	err = serializer.ReadProtobuffFromFile(filename, laptop)
+	if err != nil {
+		return nil, err
+	}
+	return []*pb.Laptop{laptop}, nil
+}
+
+// createLaptops sends every record to CreateLaptop with at most workers calls
+// in flight, and returns the records that failed
+func createLaptops(laptopClient pb.LaptopServiceClient, records []record, workers int, timeout time.Duration) []failure {
+	queue := make(chan record)
+	failures := []failure{}
+	mutex := sync.Mutex{}
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for rec := range queue {
+				err := createLaptop(laptopClient, rec.laptop, timeout)
+				if err == nil {
+					continue
+				}
+
+				mutex.Lock()
+				failures = append(failures, failure{
+					source: rec.source,
+					id:     rec.laptop.GetId(),
+					code:   status.Code(err),
+					err:    err,
+				})
+				mutex.Unlock()
+			}
+		}()
+	}
+
+	for _, rec := range records {
+		queue <- rec
+	}
+	close(queue)
+	wg.Wait()
+
+	return failures
+}
+
+func createLaptop(laptopClient pb.LaptopServiceClient, laptop *pb.Laptop, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req := &pb.CreateLaptopRequest{
+		Laptop: laptop,
+	}
+
+	_, err := laptopClient.CreateLaptop(ctx, req)
+	return err
+}
+
+// printImportSummary logs every failure, then the totals per status code
+func printImportSummary(total int, readFailures []failure, createFailures []failure) {
+	failures := append(readFailures, createFailures...)
+	sort.SliceStable(failures, func(i, j int) bool {
+		return failures[i].source < failures[j].source
+	})
+
+	byCode := make(map[codes.Code]int)
+	for _, f := range failures {
+		byCode[f.code]++
+
+		message := f.err.Error()
+		if st, ok := status.FromError(f.err); ok {
+			message = st.Message()
+		}
+		log.Printf("FAIL %s id=%q code=%s: %s", f.source, f.id, f.code, message)
+	}
+
+	log.Printf(
+		"summary: %d laptops read, %d created, %d rejected, %d unreadable inputs",
+		total, total-len(createFailures), len(createFailures), len(readFailures),
+	)
+
+	codeList := make([]codes.Code, 0, len(byCode))
+	for code := range byCode {
+		codeList = append(codeList, code)
+	}
+	sort.Slice(codeList, func(i, j int) bool {
+		return codeList[i] < codeList[j]
+	})
+	for _, code := range codeList {
+		log.Printf("  %s: %d", code, byCode[code])
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const usage = `usage:
+  catalog import [-server address] [-workers n] [-timeout d] file-or-dir...
+  catalog export [-server address] [-format name] [-timeout d] -out file-or-dir
+
+import reads laptops from .json, .ndjson, .jsonl, .bin, .txt and .yaml files,
+or from every such file in a directory, and creates them with CreateLaptop.
+It exits with status 1 when a laptop is rejected or an input is unreadable.
+export writes every laptop of the store to an .ndjson file, or to a directory
+with one file per laptop in the given format.
+`
+
+func main() {
+	log.SetFlags(0)
+
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "import":
+		err = runImport(os.Args[2:])
+	case "export":
+		err = runExport(os.Args[2:])
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func addServerFlag(flags *flag.FlagSet) *string {
+	return flags.String("server", "localhost:8080", "address of the laptop service")
+}
+
+func dial(address string) (*grpc.ClientConn, error) {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("cannot dial server: %w", err)
+	}
+	return conn, nil
+}
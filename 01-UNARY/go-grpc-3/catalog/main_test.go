@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"grpc-3/pb"
+	"grpc-3/sample"
+	"grpc-3/serializer"
+	"grpc-3/service"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestImportExportRoundTrip(t *testing.T) {
+	address := startTestServer(t)
+	dir := t.TempDir()
+
+	laptops := []*pb.Laptop{sample.NewLaptop(), sample.NewLaptop(), sample.NewLaptop()}
+	input := filepath.Join(dir, "laptops.ndjson")
+	require.NoError(t, serializer.WriteProtobuffsToNDJSONFile(laptops, input))
+	single := sample.NewLaptop()
+	require.NoError(t, serializer.WriteProtobuffToFile(single, filepath.Join(dir, "single.yaml")))
+	laptops = append(laptops, single)
+
+	err := runImport([]string{"-server", address, dir})
+	require.NoError(t, err)
+
+	output := filepath.Join(t.TempDir(), "export.ndjson")
+	err = runExport([]string{"-server", address, "-out", output})
+	require.NoError(t, err)
+	exported, err := serializer.ReadProtobuffsFromNDJSONFile(output, func() *pb.Laptop {
+		return &pb.Laptop{}
+	})
+	require.NoError(t, err)
+	requireSameLaptops(t, laptops, exported)
+
+	outputDir := filepath.Join(t.TempDir(), "laptops")
+	err = runExport([]string{"-server", address, "-out", outputDir, "-format", "binary"})
+	require.NoError(t, err)
+	entries, err := os.ReadDir(outputDir)
+	require.NoError(t, err)
+	exported = nil
+	for _, entry := range entries {
+		laptop := &pb.Laptop{}
+		require.NoError(t, serializer.ReadProtobuffFromFile(filepath.Join(outputDir, entry.Name()), laptop))
+		exported = append(exported, laptop)
+	}
+	requireSameLaptops(t, laptops, exported)
+}
+
+func TestImportReportsFailures(t *testing.T) {
+	address := startTestServer(t)
+	dir := t.TempDir()
+
+	invalid := sample.NewLaptop()
+	invalid.PriceUsd = -1
+	input := filepath.Join(dir, "laptops.ndjson")
+	require.NoError(t, serializer.WriteProtobuffsToNDJSONFile([]*pb.Laptop{sample.NewLaptop(), invalid}, input))
+	missing := filepath.Join(dir, "missing.json")
+
+	var output bytes.Buffer
+	log.SetOutput(&output)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	err := runImport([]string{"-server", address, input, missing})
+	require.EqualError(t, err, "import is incomplete: 1 rejected, 1 unreadable inputs")
+	require.Contains(t, output.String(), "FAIL "+input+":2 id=\""+invalid.Id+"\" code=InvalidArgument")
+	require.Contains(t, output.String(), "FAIL "+missing)
+	require.Contains(t, output.String(), "summary: 2 laptops read, 1 created, 1 rejected, 1 unreadable inputs")
+}
+
+// startTestServer serves LaptopService with an in-memory store on a local
+// port and returns its address. The server is stopped when the test finishes.
+func startTestServer(t *testing.T) string {
+	laptopServer := service.NewLaptopServer(service.NewInMemoryLaptopStore(), nil, nil)
+	grpcServer := grpc.NewServer()
+	pb.RegisterLaptopServiceServer(grpcServer, laptopServer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	served := make(chan error, 1)
+	go func() {
+		served <- grpcServer.Serve(listener)
+	}()
+
+	t.Cleanup(func() {
+		grpcServer.GracefulStop()
+		require.NoError(t, <-served)
+	})
+
+	return listener.Addr().String()
+}
+
+// requireSameLaptops asserts that two lists hold proto-equal laptops, in any order
+func requireSameLaptops(t *testing.T, expected []*pb.Laptop, actual []*pb.Laptop) {
+	t.Helper()
+
+	require.Len(t, actual, len(expected))
+	byID := func(laptops []*pb.Laptop) {
+		sort.Slice(laptops, func(i, j int) bool {
+			return laptops[i].GetId() < laptops[j].GetId()
+		})
+	}
+	byID(expected)
+	byID(actual)
+	for i := range expected {
+		require.True(t, proto.Equal(expected[i], actual[i]), "expected %v, got %v", expected[i], actual[i])
+	}
+}
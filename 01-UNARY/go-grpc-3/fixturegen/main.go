@@ -0,0 +1,113 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"grpc-3/pb"
+	"grpc-3/sample"
+	"grpc-3/serializer"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	defaults := sample.DefaultOptions()
+
+	seed := flag.Int64("seed", 1, "seed of the sample generator, the same seed writes the same corpus")
+	count := flag.Int("n", 10, "number of laptops to write")
+	dir := flag.String("dir", "fixtures", "directory to write the laptops to")
+	formatName := flag.String("format", "json", "output format: binary, json, text, yaml or ndjson")
+	brands := flag.String("brands", "Apple=1,Dell=1,Lenovo=1", "brand mix as comma separated brand=weight pairs")
+	minPrice := flag.Float64("min-price", defaults.MinPriceUsd, "minimum laptop price in USD")
+	maxPrice := flag.Float64("max-price", defaults.MaxPriceUsd, "maximum laptop price in USD")
+	minGPUs := flag.Int("min-gpus", defaults.MinGPUs, "minimum number of GPUs per laptop")
+	maxGPUs := flag.Int("max-gpus", defaults.MaxGPUs, "maximum number of GPUs per laptop")
+	flag.Parse()
+
+	if *count < 0 {
+		usageError("-n must not be negative, got %d", *count)
+	}
+	if *minPrice < 0 {
+		usageError("-min-price must not be negative, got %v", *minPrice)
+	}
+	if *minPrice > *maxPrice {
+		usageError("-min-price %v is above -max-price %v", *minPrice, *maxPrice)
+	}
+
+	format, err := serializer.ParseFormat(*formatName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	brandWeights, err := parseBrandWeights(*brands)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	generator := sample.NewGenerator(*seed, sample.Options{
+		BrandWeights: brandWeights,
+		MinPriceUsd:  *minPrice,
+		MaxPriceUsd:  *maxPrice,
+		MinGPUs:      *minGPUs,
+		MaxGPUs:      *maxGPUs,
+	})
+
+	laptops := make([]*pb.Laptop, *count)
+	for i := range laptops {
+		laptops[i] = generator.NewLaptop()
+	}
+
+	err = os.MkdirAll(*dir, 0755)
+	if err != nil {
+		log.Fatalf("cannot create output directory: %v", err)
+	}
+
+	// ndjson holds the whole corpus in one file, other formats one laptop per file
+	if format == serializer.FormatNDJSON {
+		filename := filepath.Join(*dir, "laptops"+format.Extension())
+		err = serializer.WriteProtobuffsToNDJSONFile(laptops, filename)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("wrote %d laptops to %s", len(laptops), filename)
+		return
+	}
+
+	for i, laptop := range laptops {
+		filename := filepath.Join(*dir, fmt.Sprintf("laptop-%04d%s", i+1, format.Extension()))
+		err = serializer.WriteProtobuffToFile(laptop, filename)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	log.Printf("wrote %d laptops to %s", len(laptops), *dir)
+}
+
+// usageError reports a bad flag value with the usage and exits with status 2,
+// like the flag package does for a flag it cannot parse
+func usageError(format string, args ...any) {
+	fmt.Fprintf(flag.CommandLine.Output(), format+"\n", args...)
+	flag.Usage()
+	os.Exit(2)
+}
+
+// parseBrandWeights parses "Apple=1,Dell=3" into a brand weight map
+func parseBrandWeights(value string) (map[string]int, error) {
+	weights := make(map[string]int)
+	for _, pair := range strings.Split(value, ",") {
+		brand, weight, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid brand weight %q, expected brand=weight", pair)
+		}
+
+		n, err := strconv.Atoi(weight)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid weight for brand %s: %q", brand, weight)
+		}
+		weights[brand] = n
+	}
+	return weights, nil
+}
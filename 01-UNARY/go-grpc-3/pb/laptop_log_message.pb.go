@@ -0,0 +1,190 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v3.12.4
+// source: laptop_log_message.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// LaptopLogEntry is one record of the file-backed laptop store
+type LaptopLogEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Entry:
+	//	*LaptopLogEntry_Saved
+	//	*LaptopLogEntry_DeletedId
+	Entry isLaptopLogEntry_Entry `protobuf_oneof:"entry"`
+}
+
+func (x *LaptopLogEntry) Reset() {
+	*x = LaptopLogEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_laptop_log_message_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LaptopLogEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LaptopLogEntry) ProtoMessage() {}
+
+func (x *LaptopLogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_laptop_log_message_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LaptopLogEntry.ProtoReflect.Descriptor instead.
+func (*LaptopLogEntry) Descriptor() ([]byte, []int) {
+	return file_laptop_log_message_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *LaptopLogEntry) GetEntry() isLaptopLogEntry_Entry {
+	if m != nil {
+		return m.Entry
+	}
+	return nil
+}
+
+func (x *LaptopLogEntry) GetSaved() *Laptop {
+	if x, ok := x.GetEntry().(*LaptopLogEntry_Saved); ok {
+		return x.Saved
+	}
+	return nil
+}
+
+func (x *LaptopLogEntry) GetDeletedId() string {
+	if x, ok := x.GetEntry().(*LaptopLogEntry_DeletedId); ok {
+		return x.DeletedId
+	}
+	return ""
+}
+
+type isLaptopLogEntry_Entry interface {
+	isLaptopLogEntry_Entry()
+}
+
+type LaptopLogEntry_Saved struct {
+	Saved *Laptop `protobuf:"bytes,1,opt,name=saved,proto3,oneof"`
+}
+
+type LaptopLogEntry_DeletedId struct {
+	DeletedId string `protobuf:"bytes,2,opt,name=deleted_id,json=deletedId,proto3,oneof"`
+}
+
+func (*LaptopLogEntry_Saved) isLaptopLogEntry_Entry() {}
+
+func (*LaptopLogEntry_DeletedId) isLaptopLogEntry_Entry() {}
+
+var File_laptop_log_message_proto protoreflect.FileDescriptor
+
+var file_laptop_log_message_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x6c, 0x61, 0x70, 0x74, 0x6f, 0x70, 0x5f, 0x6c, 0x6f, 0x67, 0x5f, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x11, 0x74, 0x65, 0x63, 0x68,
+	0x73, 0x63, 0x68, 0x6f, 0x6f, 0x6c, 0x2e, 0x70, 0x63, 0x62, 0x6f, 0x6f, 0x6b, 0x1a, 0x14, 0x6c,
+	0x61, 0x70, 0x74, 0x6f, 0x70, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0x6d, 0x0a, 0x0e, 0x4c, 0x61, 0x70, 0x74, 0x6f, 0x70, 0x4c, 0x6f, 0x67,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x31, 0x0a, 0x05, 0x73, 0x61, 0x76, 0x65, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74, 0x65, 0x63, 0x68, 0x73, 0x63, 0x68, 0x6f, 0x6f,
+	0x6c, 0x2e, 0x70, 0x63, 0x62, 0x6f, 0x6f, 0x6b, 0x2e, 0x4c, 0x61, 0x70, 0x74, 0x6f, 0x70, 0x48,
+	0x00, 0x52, 0x05, 0x73, 0x61, 0x76, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0a, 0x64, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x09,
+	0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x49, 0x64, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x6e, 0x74,
+	0x72, 0x79, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2e, 0x2f, 0x70, 0x62, 0x3b, 0x70, 0x62, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_laptop_log_message_proto_rawDescOnce sync.Once
+	file_laptop_log_message_proto_rawDescData = file_laptop_log_message_proto_rawDesc
+)
+
+func file_laptop_log_message_proto_rawDescGZIP() []byte {
+	file_laptop_log_message_proto_rawDescOnce.Do(func() {
+		file_laptop_log_message_proto_rawDescData = protoimpl.X.CompressGZIP(file_laptop_log_message_proto_rawDescData)
+	})
+	return file_laptop_log_message_proto_rawDescData
+}
+
+var file_laptop_log_message_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_laptop_log_message_proto_goTypes = []any{
+	(*LaptopLogEntry)(nil), // 0: techschool.pcbook.LaptopLogEntry
+	(*Laptop)(nil),         // 1: techschool.pcbook.Laptop
+}
+var file_laptop_log_message_proto_depIdxs = []int32{
+	1, // 0: techschool.pcbook.LaptopLogEntry.saved:type_name -> techschool.pcbook.Laptop
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_laptop_log_message_proto_init() }
+func file_laptop_log_message_proto_init() {
+	if File_laptop_log_message_proto != nil {
+		return
+	}
+	file_laptop_message_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_laptop_log_message_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*LaptopLogEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_laptop_log_message_proto_msgTypes[0].OneofWrappers = []any{
+		(*LaptopLogEntry_Saved)(nil),
+		(*LaptopLogEntry_DeletedId)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_laptop_log_message_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_laptop_log_message_proto_goTypes,
+		DependencyIndexes: file_laptop_log_message_proto_depIdxs,
+		MessageInfos:      file_laptop_log_message_proto_msgTypes,
+	}.Build()
+	File_laptop_log_message_proto = out.File
+	file_laptop_log_message_proto_rawDesc = nil
+	file_laptop_log_message_proto_goTypes = nil
+	file_laptop_log_message_proto_depIdxs = nil
+}
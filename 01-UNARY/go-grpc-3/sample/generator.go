@@ -2,50 +2,207 @@ package sample
 
 import (
 	"grpc-3/pb"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
 
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-func NewKeyboard() *pb.Keyboard{
+// Options tunes the laptops made by a Generator
+type Options struct {
+	// BrandWeights sets how often each laptop brand is picked relative to the
+	// others, such as {"Apple": 1, "Dell": 3}
+	BrandWeights map[string]int
+	// MinPriceUsd and MaxPriceUsd bound the laptop price
+	MinPriceUsd float64
+	MaxPriceUsd float64
+	// MinGPUs and MaxGPUs bound the number of GPUs of a laptop
+	MinGPUs int
+	MaxGPUs int
+}
+
+// DefaultOptions returns the options used by the package-level functions
+func DefaultOptions() Options {
+	return Options{
+		BrandWeights: map[string]int{"Apple": 1, "Dell": 1, "Lenovo": 1},
+		MinPriceUsd:  1500,
+		MaxPriceUsd:  3000,
+		MinGPUs:      1,
+		MaxGPUs:      1,
+	}
+}
+
+// Generator makes random laptops from an explicit seed. Two generators built
+// with the same seed and options produce identical laptops, call for call.
+// A Generator is safe for concurrent use, but the order of concurrent calls
+// decides which laptop each caller gets.
+type Generator struct {
+	mutex   sync.Mutex
+	rand    *rand.Rand
+	options Options
+	brands  []string
+}
+
+// NewGenerator creates a Generator seeded with seed
+func NewGenerator(seed int64, options Options) *Generator {
+	brands := make([]string, 0, len(options.BrandWeights))
+	for brand, weight := range options.BrandWeights {
+		if weight > 0 {
+			brands = append(brands, brand)
+		}
+	}
+	// map order is random, sort so that the seed alone decides the brand
+	sort.Strings(brands)
+
+	if options.MinGPUs < 0 {
+		options.MinGPUs = 0
+	}
+	if options.MaxGPUs < options.MinGPUs {
+		options.MaxGPUs = options.MinGPUs
+	}
+
+	return &Generator{
+		rand:    rand.New(rand.NewSource(seed)),
+		options: options,
+		brands:  brands,
+	}
+}
+
+var defaultGenerator = NewGenerator(time.Now().UnixNano(), DefaultOptions())
+
+func NewKeyboard() *pb.Keyboard {
+	return defaultGenerator.NewKeyboard()
+}
+
+func NewCPU() *pb.CPU {
+	return defaultGenerator.NewCPU()
+}
+
+func NewGPU() *pb.GPU {
+	return defaultGenerator.NewGPU()
+}
+
+func NewRam() *pb.Memory {
+	return defaultGenerator.NewRam()
+}
+
+func NewSSD() *pb.Storage {
+	return defaultGenerator.NewSSD()
+}
+
+func NewHDD() *pb.Storage {
+	return defaultGenerator.NewHDD()
+}
+
+func NewScreen() *pb.Screen {
+	return defaultGenerator.NewScreen()
+}
+
+func NewLaptop() *pb.Laptop {
+	return defaultGenerator.NewLaptop()
+}
+
+func (g *Generator) NewKeyboard() *pb.Keyboard {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return g.newKeyboard()
+}
+
+func (g *Generator) NewCPU() *pb.CPU {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return g.newCPU()
+}
+
+func (g *Generator) NewGPU() *pb.GPU {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return g.newGPU()
+}
+
+func (g *Generator) NewRam() *pb.Memory {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return g.newRam()
+}
+
+func (g *Generator) NewSSD() *pb.Storage {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return g.newSSD()
+}
+
+func (g *Generator) NewHDD() *pb.Storage {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return g.newHDD()
+}
+
+func (g *Generator) NewScreen() *pb.Screen {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return g.newScreen()
+}
+
+func (g *Generator) NewLaptop() *pb.Laptop {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return g.newLaptop()
+}
+
+func (g *Generator) newKeyboard() *pb.Keyboard {
 	keyboard := &pb.Keyboard{
-		Layout: randomKeyboardLayout(),
-		Backlit: randomBool(),
+		Layout:  g.randomKeyboardLayout(),
+		Backlit: g.randomBool(),
 	}
 	return keyboard
 }
 
-func NewCPU() *pb.CPU{	
-	brand := randomCPUBrand()
-	name := randomCPUName(brand)
+func (g *Generator) newCPU() *pb.CPU {
+	brand := g.randomCPUBrand()
+	name := g.randomCPUName(brand)
+
+	numberCores := g.randomInt(2, 8)
+	numberThreads := g.randomInt(numberCores, 12)
 
-	minGhx := randomFloat64(2.5, 3.5)
-	maxGhx := randomFloat64(minGhx, 5.0)
+	minGhx := g.randomFloat64(2.5, 3.5)
+	maxGhx := g.randomFloat64(minGhx, 5.0)
 
 	cpu := &pb.CPU{
-		Brand: brand,
-		Name: name,
-		NumberCores: uint32(randomInt(2, 8)),
-		NumberThreads: uint32(randomInt(2, 8)),
-		MinGhz: minGhx,
-		MaxGhz: maxGhx,
+		Brand:         brand,
+		Name:          name,
+		NumberCores:   uint32(numberCores),
+		NumberThreads: uint32(numberThreads),
+		MinGhz:        minGhx,
+		MaxGhz:        maxGhx,
 	}
 	return cpu
 }
 
-func NewGPU() *pb.GPU{
-	brand := randomGPUBrand()
+func (g *Generator) newGPU() *pb.GPU {
+	brand := g.randomGPUBrand()
 
-	minGhz := randomFloat64(1.0, 1.5)
-	maxGhz := randomFloat64(minGhz, 2.0)
+	minGhz := g.randomFloat64(1.0, 1.5)
+	maxGhz := g.randomFloat64(minGhz, 2.0)
 
 	memory := &pb.Memory{
-		Value: uint64(randomInt(2, 6)),
-		Unit: pb.Memory_GIGABYTE,
+		Value: uint64(g.randomInt(2, 6)),
+		Unit:  pb.Memory_GIGABYTE,
 	}
 
 	gpu := &pb.GPU{
-		Brand: brand,
-		Name: randomGPUName(brand),
+		Brand:  brand,
+		Name:   g.randomGPUName(brand),
 		MinGhz: minGhz,
 		MaxGhz: maxGhz,
 		Memory: memory,
@@ -53,67 +210,73 @@ func NewGPU() *pb.GPU{
 	return gpu
 }
 
-func NewRam() *pb.Memory{
+func (g *Generator) newRam() *pb.Memory {
 	memory := &pb.Memory{
-		Value: uint64(randomInt(4, 64)),
-		Unit: pb.Memory_GIGABYTE,
+		Value: uint64(g.randomInt(4, 64)),
+		Unit:  pb.Memory_GIGABYTE,
 	}
 	return memory
 }
 
-func NewSSD() *pb.Storage{
+func (g *Generator) newSSD() *pb.Storage {
 	storage := &pb.Storage{
 		Driver: pb.Storage_SSD,
 		Memory: &pb.Memory{
-			Value: uint64(randomInt(128, 1024)),
-			Unit: pb.Memory_GIGABYTE,
+			Value: uint64(g.randomInt(128, 1024)),
+			Unit:  pb.Memory_GIGABYTE,
 		},
 	}
 	return storage
 }
 
-func NewHDD() *pb.Storage{
+func (g *Generator) newHDD() *pb.Storage {
 	storage := &pb.Storage{
 		Driver: pb.Storage_HDD,
 		Memory: &pb.Memory{
-			Value: uint64(randomInt(1, 6)),
-			Unit: pb.Memory_TERABYTE,
+			Value: uint64(g.randomInt(1, 6)),
+			Unit:  pb.Memory_TERABYTE,
 		},
 	}
 	return storage
 }
 
-func NewScreen() *pb.Screen{
-
+func (g *Generator) newScreen() *pb.Screen {
 	screen := &pb.Screen{
-		SizeInch: float32(randomFloat64(13, 17)),
-		Resolution: randomScreenResolution(),
-		Panel: randomPanel(),
-		Multitouch: randomBool(),
+		SizeInch:   float32(g.randomFloat64(13, 17)),
+		Resolution: g.randomScreenResolution(),
+		Panel:      g.randomPanel(),
+		Multitouch: g.randomBool(),
 	}
 	return screen
 }
 
-func NewLaptop() *pb.Laptop{
-	brand := randomLaptopBrand()
-	name := randomLaptopName(brand)
+func (g *Generator) newLaptop() *pb.Laptop {
+	brand := g.randomLaptopBrand()
+	name := g.randomLaptopName(brand)
+
+	gpus := []*pb.GPU{}
+	for i := g.randomInt(g.options.MinGPUs, g.options.MaxGPUs); i > 0; i-- {
+		gpus = append(gpus, g.newGPU())
+	}
+
+	releaseYear := g.randomInt(2015, 2024)
 
 	laptop := &pb.Laptop{
-		Id: randomID(),
-		Brand: brand,
-		Name: name,
-		Cpu: NewCPU(),
-		Ram: NewRam(),
-		Gpus: []*pb.GPU{NewGPU()},
-		Storages: []*pb.Storage{NewSSD(), NewHDD()},
-		Screen: NewScreen(),
-		Keyboard: NewKeyboard(),
+		Id:       g.randomID(),
+		Brand:    brand,
+		Name:     name,
+		Cpu:      g.newCPU(),
+		Ram:      g.newRam(),
+		Gpus:     gpus,
+		Storages: []*pb.Storage{g.newSSD(), g.newHDD()},
+		Screen:   g.newScreen(),
+		Keyboard: g.newKeyboard(),
 		Weight: &pb.Laptop_WeightKg{
-			WeightKg: randomFloat64(1.0, 3.0),
+			WeightKg: g.randomFloat64(1.0, 3.0),
 		},
-		PriceUsd: randomFloat64(1500, 3000),
-		ReleaseYear: uint32(randomInt(2015, 2024)),
-		UpdatedAt: timestamppb.Now(),
+		PriceUsd:    g.randomFloat64(g.options.MinPriceUsd, g.options.MaxPriceUsd),
+		ReleaseYear: uint32(releaseYear),
+		UpdatedAt:   timestamppb.New(g.randomTimeInYear(releaseYear)),
 	}
 	return laptop
-}
\ No newline at end of file
+}
@@ -0,0 +1,49 @@
+package sample_test
+
+import (
+	"grpc-3/sample"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGeneratorSameSeed(t *testing.T) {
+	t.Parallel()
+
+	generator1 := sample.NewGenerator(42, sample.DefaultOptions())
+	generator2 := sample.NewGenerator(42, sample.DefaultOptions())
+	generator3 := sample.NewGenerator(43, sample.DefaultOptions())
+
+	for i := 0; i < 20; i++ {
+		laptop1 := generator1.NewLaptop()
+		laptop2 := generator2.NewLaptop()
+		laptop3 := generator3.NewLaptop()
+
+		require.True(t, proto.Equal(laptop1, laptop2))
+		require.False(t, proto.Equal(laptop1, laptop3))
+	}
+}
+
+func TestGeneratorOptions(t *testing.T) {
+	t.Parallel()
+
+	options := sample.Options{
+		BrandWeights: map[string]int{"Apple": 1, "Dell": 0},
+		MinPriceUsd:  500,
+		MaxPriceUsd:  800,
+		MinGPUs:      2,
+		MaxGPUs:      3,
+	}
+	generator := sample.NewGenerator(7, options)
+
+	for i := 0; i < 50; i++ {
+		laptop := generator.NewLaptop()
+
+		require.Equal(t, "Apple", laptop.GetBrand())
+		require.GreaterOrEqual(t, laptop.GetPriceUsd(), 500.0)
+		require.LessOrEqual(t, laptop.GetPriceUsd(), 800.0)
+		require.GreaterOrEqual(t, len(laptop.GetGpus()), 2)
+		require.LessOrEqual(t, len(laptop.GetGpus()), 3)
+	}
+}
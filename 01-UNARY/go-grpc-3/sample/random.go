@@ -2,22 +2,17 @@ package sample
 
 import (
 	"grpc-3/pb"
-	"math/rand"
 	"time"
 
 	"github.com/google/uuid"
 )
 
-func init() {
-	rand.Seed(time.Now().UnixNano())
+func (g *Generator) randomBool() bool {
+	return g.rand.Intn(2) == 1
 }
 
-func randomBool() bool{
-	return rand.Intn(2) == 1 
-}
-
-func randomKeyboardLayout() pb.Keyboard_Layout{
-	switch rand.Intn(3) {
+func (g *Generator) randomKeyboardLayout() pb.Keyboard_Layout {
+	switch g.rand.Intn(3) {
 	case 1:
 		return pb.Keyboard_QWERTZ
 	case 2:
@@ -27,21 +22,21 @@ func randomKeyboardLayout() pb.Keyboard_Layout{
 	}
 }
 
-func randomCPUBrand() string{
-	return randomStringFromSet("Intel", "AMD")
+func (g *Generator) randomCPUBrand() string {
+	return g.randomStringFromSet("Intel", "AMD")
 }
 
-func randomStringFromSet(a ...string) string {
+func (g *Generator) randomStringFromSet(a ...string) string {
 	n := len(a)
 	if n == 0 {
 		return ""
 	}
-	return a[rand.Intn(n)]
+	return a[g.rand.Intn(n)]
 }
 
-func randomCPUName(brand string) string{
+func (g *Generator) randomCPUName(brand string) string {
 	if brand == "Intel" {
-		return randomStringFromSet(
+		return g.randomStringFromSet(
 			"Xeon E-2286M",
 			"Core i9-9980HK",
 			"Core i7-8700K",
@@ -49,28 +44,27 @@ func randomCPUName(brand string) string{
 			"Core i3-1005G1",
 		)
 	}
-	return randomStringFromSet(
+	return g.randomStringFromSet(
 		"Ryzen 7 2700",
 		"Ryzen 5 3600",
 		"Ryzen 3 3200G",
 	)
 }
 
-
-func randomGPUBrand() string {
-	return randomStringFromSet("Nvidia", "AMD")	
+func (g *Generator) randomGPUBrand() string {
+	return g.randomStringFromSet("Nvidia", "AMD")
 }
 
-func randomGPUName(brand string) string{
+func (g *Generator) randomGPUName(brand string) string {
 	if brand == "Nvidia" {
-		return randomStringFromSet(
+		return g.randomStringFromSet(
 			"RTX 2060",
 			"RTX 2070",
 			"GTX 1660-Ti",
 			"GTX 1070",
 		)
 	}
-	return randomStringFromSet(
+	return g.randomStringFromSet(
 		"RX 590",
 		"RX 580",
 		"RX 5700",
@@ -78,46 +72,74 @@ func randomGPUName(brand string) string{
 	)
 }
 
-func randomPanel() pb.Screen_Panel{
-	if randomBool() {
+func (g *Generator) randomPanel() pb.Screen_Panel {
+	if g.randomBool() {
 		return pb.Screen_OLED
 	}
 	return pb.Screen_IPS
 }
 
-func randomScreenResolution() *pb.Screen_Resolution{
-	height := randomInt(1080, 4320)
-	width := height * 16 / 9;
+func (g *Generator) randomScreenResolution() *pb.Screen_Resolution {
+	height := g.randomInt(1080, 4320)
+	width := height * 16 / 9
 
 	return &pb.Screen_Resolution{
-		Width: uint32(height),
-		Height: uint32(width),
+		Width:  uint32(width),
+		Height: uint32(height),
 	}
 }
 
-func randomLaptopBrand() string {
-	return randomStringFromSet("Apple", "Dell", "Lenovo")
+// randomLaptopBrand picks a brand with a probability proportional to its weight
+func (g *Generator) randomLaptopBrand() string {
+	total := 0
+	for _, brand := range g.brands {
+		total += g.options.BrandWeights[brand]
+	}
+	if total == 0 {
+		return ""
+	}
+
+	n := g.rand.Intn(total)
+	for _, brand := range g.brands {
+		n -= g.options.BrandWeights[brand]
+		if n < 0 {
+			return brand
+		}
+	}
+	return g.brands[len(g.brands)-1]
 }
 
-func randomLaptopName(brand string) string {
+func (g *Generator) randomLaptopName(brand string) string {
 	switch brand {
 	case "Apple":
-		return randomStringFromSet("Macbook Air", "Macbook Pro")
-	case "Dell":	
-		return randomStringFromSet("Latitude", "XPS", "Vostro")
+		return g.randomStringFromSet("Macbook Air", "Macbook Pro")
+	case "Dell":
+		return g.randomStringFromSet("Latitude", "XPS", "Vostro")
 	default:
-		return randomStringFromSet("Thinkpad X1", "Thinkpad P1")
+		return g.randomStringFromSet("Thinkpad X1", "Thinkpad P1")
 	}
 }
 
-func randomInt(min int, max int) int{
-	return min + rand.Intn(max-min+1)
+func (g *Generator) randomInt(min int, max int) int {
+	return min + g.rand.Intn(max-min+1)
+}
+
+func (g *Generator) randomFloat64(min float64, max float64) float64 {
+	return min + g.rand.Float64()*(max-min)
 }
 
-func randomFloat64(min float64, max float64) float64 {
-	return min + rand.Float64()*(max-min)
+// randomTimeInYear returns a time to the second within the given UTC year
+func (g *Generator) randomTimeInYear(year int) time.Time {
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(1, 0, 0)
+	return start.Add(time.Duration(g.rand.Int63n(int64(end.Sub(start).Seconds()))) * time.Second)
 }
 
-func randomID() string{
-	return uuid.New().String()
-}
\ No newline at end of file
+func (g *Generator) randomID() string {
+	id, err := uuid.NewRandomFromReader(g.rand)
+	if err != nil {
+		// reading from a math/rand source never fails
+		panic(err)
+	}
+	return id.String()
+}
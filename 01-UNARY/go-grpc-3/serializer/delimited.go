@@ -0,0 +1,68 @@
+package serializer
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// MaxDelimitedMessageSize guards against reading a corrupt length prefix
+const MaxDelimitedMessageSize = 64 << 20
+
+// WriteDelimitedProtobuff writes message to w as a 4-byte big-endian length
+// followed by its binary encoding, and returns the number of bytes written
+func WriteDelimitedProtobuff(w io.Writer, message proto.Message) (int, error) {
+	data, err := proto.Marshal(message)
+	if err != nil {
+		return 0, fmt.Errorf("cannot marshal proto message: %w", err)
+	}
+
+	record := make([]byte, 4+len(data))
+	binary.BigEndian.PutUint32(record, uint32(len(data)))
+	copy(record[4:], data)
+
+	n, err := w.Write(record)
+	if err != nil {
+		return n, fmt.Errorf("cannot write delimited record: %w", err)
+	}
+	return n, nil
+}
+
+// ReadDelimitedProtobuff reads one record written by WriteDelimitedProtobuff
+// into message and returns the number of bytes consumed. It returns io.EOF
+// when r is exhausted on a record boundary, and an error wrapping
+// io.ErrUnexpectedEOF when the last record is incomplete.
+func ReadDelimitedProtobuff(r io.Reader, message proto.Message) (int, error) {
+	header := make([]byte, 4)
+	n, err := io.ReadFull(r, header)
+	if err == io.EOF {
+		return 0, io.EOF
+	}
+	if err != nil {
+		return n, fmt.Errorf("cannot read record length: %w", err)
+	}
+
+	size := binary.BigEndian.Uint32(header)
+	if size > MaxDelimitedMessageSize {
+		return n, fmt.Errorf("record length %d exceeds limit %d", size, MaxDelimitedMessageSize)
+	}
+
+	data := make([]byte, size)
+	m, err := io.ReadFull(r, data)
+	n += m
+	if errors.Is(err, io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return n, fmt.Errorf("cannot read record data: %w", err)
+	}
+
+	err = proto.Unmarshal(data, message)
+	if err != nil {
+		return n, fmt.Errorf("cannot unmarshal record data: %w", err)
+	}
+	return n, nil
+}
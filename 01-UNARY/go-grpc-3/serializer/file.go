@@ -45,3 +45,89 @@ func ReadProtobuffFromBinaryFile(filename string, message proto.Message) error {
 	}
 	return nil
 }
+
+// ReadProtobuffFromJSONFile reads a file written by WriteProtobuffToJSONFile
+func ReadProtobuffFromJSONFile(filename string, message proto.Message) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("cannot read json data from file: %w", err)
+	}
+	return JSONToProtobuff(string(data), message)
+}
+
+// WriteProtobuffToTextFile writes message to filename in the protobuf text format
+func WriteProtobuffToTextFile(message proto.Message, filename string) error {
+	data, err := ProtobuffToText(message)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(filename, []byte(data), 0644)
+	if err != nil {
+		return fmt.Errorf("cannot write text data to file: %w", err)
+	}
+	return nil
+}
+
+// ReadProtobuffFromTextFile reads a file written by WriteProtobuffToTextFile
+func ReadProtobuffFromTextFile(filename string, message proto.Message) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("cannot read text data from file: %w", err)
+	}
+	return TextToProtobuff(string(data), message)
+}
+
+// WriteProtobuffToYAMLFile writes message to filename as YAML
+func WriteProtobuffToYAMLFile(message proto.Message, filename string) error {
+	data, err := ProtobuffToYAML(message)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(filename, []byte(data), 0644)
+	if err != nil {
+		return fmt.Errorf("cannot write yaml data to file: %w", err)
+	}
+	return nil
+}
+
+// ReadProtobuffFromYAMLFile reads a file written by WriteProtobuffToYAMLFile
+func ReadProtobuffFromYAMLFile(filename string, message proto.Message) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("cannot read yaml data from file: %w", err)
+	}
+	return YAMLToProtobuff(string(data), message)
+}
+
+// WriteProtobuffsToNDJSONFile writes messages to filename, one JSON document per line
+func WriteProtobuffsToNDJSONFile[T proto.Message](messages []T, filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("cannot create ndjson file: %w", err)
+	}
+
+	err = WriteProtobuffsToNDJSON(file, messages)
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("cannot close ndjson file: %w", err)
+	}
+	return nil
+}
+
+// ReadProtobuffsFromNDJSONFile reads a file written by WriteProtobuffsToNDJSONFile
+func ReadProtobuffsFromNDJSONFile[T proto.Message](filename string, newMessage func() T) ([]T, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open ndjson file: %w", err)
+	}
+	defer file.Close()
+
+	return ReadProtobuffsFromNDJSON(file, newMessage)
+}
@@ -4,6 +4,7 @@ import (
 	"grpc-3/pb"
 	"grpc-3/sample"
 	"grpc-3/serializer"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -13,7 +14,7 @@ import (
 func TestFileSerializer(t *testing.T) {
 	t.Parallel()
 
-	binaryFile := "../tmp/laptop.bin"
+	binaryFile := filepath.Join(t.TempDir(), "laptop.bin")
 
 	laptop1 := sample.NewLaptop()
 	err := serializer.WriteProtobuffToBinaryFile(laptop1, binaryFile)
@@ -23,7 +24,94 @@ func TestFileSerializer(t *testing.T) {
 	err = serializer.ReadProtobuffFromBinaryFile(binaryFile, laptop2)
 	require.NoError(t, err)
 	require.True(t, proto.Equal(laptop1, laptop2))
+}
+
+func TestJSONFileSerializer(t *testing.T) {
+	t.Parallel()
+
+	jsonFile := filepath.Join(t.TempDir(), "laptop.json")
+
+	laptop1 := sample.NewLaptop()
+	err := serializer.WriteProtobuffToJSONFile(laptop1, jsonFile)
+	require.NoError(t, err)
+
+	laptop2 := &pb.Laptop{}
+	err = serializer.ReadProtobuffFromJSONFile(jsonFile, laptop2)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(laptop1, laptop2))
+}
+
+func TestTextFileSerializer(t *testing.T) {
+	t.Parallel()
+
+	textFile := filepath.Join(t.TempDir(), "laptop.txt")
+
+	laptop1 := sample.NewLaptop()
+	err := serializer.WriteProtobuffToTextFile(laptop1, textFile)
+	require.NoError(t, err)
+
+	laptop2 := &pb.Laptop{}
+	err = serializer.ReadProtobuffFromTextFile(textFile, laptop2)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(laptop1, laptop2))
+}
+
+func TestYAMLFileSerializer(t *testing.T) {
+	t.Parallel()
+
+	yamlFile := filepath.Join(t.TempDir(), "laptop.yaml")
+
+	laptop1 := sample.NewLaptop()
+	err := serializer.WriteProtobuffToYAMLFile(laptop1, yamlFile)
+	require.NoError(t, err)
+
+	laptop2 := &pb.Laptop{}
+	err = serializer.ReadProtobuffFromYAMLFile(yamlFile, laptop2)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(laptop1, laptop2))
+}
+
+func TestNDJSONFileSerializer(t *testing.T) {
+	t.Parallel()
 
-	err = serializer.WriteProtobuffToJSONFile(laptop1, "../tmp/laptop.json")
+	ndjsonFile := filepath.Join(t.TempDir(), "laptops.ndjson")
+
+	laptops1 := []*pb.Laptop{}
+	for i := 0; i < 5; i++ {
+		laptops1 = append(laptops1, sample.NewLaptop())
+	}
+
+	err := serializer.WriteProtobuffsToNDJSONFile(laptops1, ndjsonFile)
+	require.NoError(t, err)
+
+	laptops2, err := serializer.ReadProtobuffsFromNDJSONFile(ndjsonFile, func() *pb.Laptop {
+		return &pb.Laptop{}
+	})
 	require.NoError(t, err)
+	require.Len(t, laptops2, len(laptops1))
+	for i := range laptops1 {
+		require.True(t, proto.Equal(laptops1[i], laptops2[i]))
+	}
+}
+
+func TestFormatFileSerializer(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	for _, name := range []string{"laptop.bin", "laptop.json", "laptop.txt", "laptop.yml", "laptop.jsonl"} {
+		filename := filepath.Join(dir, name)
+
+		laptop1 := sample.NewLaptop()
+		err := serializer.WriteProtobuffToFile(laptop1, filename)
+		require.NoError(t, err)
+
+		laptop2 := &pb.Laptop{}
+		err = serializer.ReadProtobuffFromFile(filename, laptop2)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(laptop1, laptop2), name)
+	}
+
+	err := serializer.WriteProtobuffToFile(sample.NewLaptop(), filepath.Join(dir, "laptop.csv"))
+	require.Error(t, err)
 }
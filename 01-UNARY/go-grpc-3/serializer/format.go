@@ -0,0 +1,135 @@
+package serializer
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Format is an on-disk encoding of protobuf messages
+type Format int
+
+const (
+	FormatBinary Format = iota
+	FormatJSON
+	FormatText
+	FormatYAML
+	FormatNDJSON
+)
+
+var formatNames = map[Format]string{
+	FormatBinary: "binary",
+	FormatJSON:   "json",
+	FormatText:   "text",
+	FormatYAML:   "yaml",
+	FormatNDJSON: "ndjson",
+}
+
+var formatExtensions = map[string]Format{
+	".bin":       FormatBinary,
+	".pb":        FormatBinary,
+	".json":      FormatJSON,
+	".txt":       FormatText,
+	".textproto": FormatText,
+	".yaml":      FormatYAML,
+	".yml":       FormatYAML,
+	".ndjson":    FormatNDJSON,
+	".jsonl":     FormatNDJSON,
+}
+
+func (format Format) String() string {
+	name, ok := formatNames[format]
+	if !ok {
+		return fmt.Sprintf("Format(%d)", int(format))
+	}
+	return name
+}
+
+// Extension returns the preferred file extension of the format
+func (format Format) Extension() string {
+	switch format {
+	case FormatJSON:
+		return ".json"
+	case FormatText:
+		return ".txt"
+	case FormatYAML:
+		return ".yaml"
+	case FormatNDJSON:
+		return ".ndjson"
+	default:
+		return ".bin"
+	}
+}
+
+// ParseFormat returns the format with the given name, such as "yaml"
+func ParseFormat(name string) (Format, error) {
+	for format, formatName := range formatNames {
+		if formatName == strings.ToLower(name) {
+			return format, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown format %q", name)
+}
+
+// FormatFromFilename picks the format from the extension of filename
+func FormatFromFilename(filename string) (Format, error) {
+	format, ok := formatExtensions[strings.ToLower(filepath.Ext(filename))]
+	if !ok {
+		return 0, fmt.Errorf("cannot tell the format of %s from its extension", filename)
+	}
+	return format, nil
+}
+
+// WriteProtobuffToFile writes message to filename in the format given by its extension
+func WriteProtobuffToFile(message proto.Message, filename string) error {
+	format, err := FormatFromFilename(filename)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case FormatJSON:
+		return WriteProtobuffToJSONFile(message, filename)
+	case FormatText:
+		return WriteProtobuffToTextFile(message, filename)
+	case FormatYAML:
+		return WriteProtobuffToYAMLFile(message, filename)
+	case FormatNDJSON:
+		return WriteProtobuffsToNDJSONFile([]proto.Message{message}, filename)
+	default:
+		return WriteProtobuffToBinaryFile(message, filename)
+	}
+}
+
+// ReadProtobuffFromFile reads message from filename in the format given by its
+// extension. An NDJSON file must hold exactly one message.
+func ReadProtobuffFromFile(filename string, message proto.Message) error {
+	format, err := FormatFromFilename(filename)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case FormatJSON:
+		return ReadProtobuffFromJSONFile(filename, message)
+	case FormatText:
+		return ReadProtobuffFromTextFile(filename, message)
+	case FormatYAML:
+		return ReadProtobuffFromYAMLFile(filename, message)
+	case FormatNDJSON:
+		messages, err := ReadProtobuffsFromNDJSONFile(filename, func() proto.Message {
+			return message
+		})
+		if err != nil {
+			return err
+		}
+		if len(messages) != 1 {
+			return fmt.Errorf("expected 1 message in %s, found %d", filename, len(messages))
+		}
+		return nil
+	default:
+		return ReadProtobuffFromBinaryFile(filename, message)
+	}
+}
@@ -25,3 +25,11 @@ func ProtobuffToJSON(message proto.Message) (string, error) {
 	return string(marshalled), nil
 }
 
+// JSONToProtobuff parses JSON written by ProtobuffToJSON into message
+func JSONToProtobuff(data string, message proto.Message) error {
+	err := protojson.Unmarshal([]byte(data), message)
+	if err != nil {
+		return fmt.Errorf("cannot unmarshal json to proto message: %w", err)
+	}
+	return nil
+}
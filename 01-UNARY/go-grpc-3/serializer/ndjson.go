@@ -0,0 +1,68 @@
+package serializer
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxNDJSONLineSize is the longest line ReadProtobuffsFromNDJSON accepts
+const maxNDJSONLineSize = 16 << 20
+
+// WriteProtobuffsToNDJSON writes each message to w as one line of compact JSON
+func WriteProtobuffsToNDJSON[T proto.Message](w io.Writer, messages []T) error {
+	options := protojson.MarshalOptions{
+		UseProtoNames: true,
+	}
+
+	writer := bufio.NewWriter(w)
+	for i, message := range messages {
+		line, err := options.Marshal(message)
+		if err != nil {
+			return fmt.Errorf("cannot marshal message %d to json: %w", i, err)
+		}
+
+		_, err = writer.Write(append(line, '\n'))
+		if err != nil {
+			return fmt.Errorf("cannot write ndjson line: %w", err)
+		}
+	}
+
+	err := writer.Flush()
+	if err != nil {
+		return fmt.Errorf("cannot write ndjson: %w", err)
+	}
+	return nil
+}
+
+// ReadProtobuffsFromNDJSON reads one message per non-empty line of r,
+// allocating each with newMessage
+func ReadProtobuffsFromNDJSON[T proto.Message](r io.Reader, newMessage func() T) ([]T, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxNDJSONLineSize)
+
+	messages := []T{}
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		message := newMessage()
+		err := protojson.Unmarshal(line, message)
+		if err != nil {
+			return nil, fmt.Errorf("cannot unmarshal ndjson line %d: %w", lineNumber, err)
+		}
+		messages = append(messages, message)
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read ndjson: %w", err)
+	}
+	return messages, nil
+}
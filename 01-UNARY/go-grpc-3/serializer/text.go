@@ -0,0 +1,32 @@
+package serializer
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+)
+
+// ProtobuffToText marshals message to the protobuf text format
+func ProtobuffToText(message proto.Message) (string, error) {
+	options := prototext.MarshalOptions{
+		Multiline: true,
+		Indent:    "  ",
+	}
+
+	marshalled, err := options.Marshal(message)
+	if err != nil {
+		return "", fmt.Errorf("cannot marshal proto message to text: %w", err)
+	}
+
+	return string(marshalled), nil
+}
+
+// TextToProtobuff parses the protobuf text format into message
+func TextToProtobuff(data string, message proto.Message) error {
+	err := prototext.Unmarshal([]byte(data), message)
+	if err != nil {
+		return fmt.Errorf("cannot unmarshal text to proto message: %w", err)
+	}
+	return nil
+}
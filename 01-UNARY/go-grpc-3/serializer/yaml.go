@@ -0,0 +1,60 @@
+package serializer
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+// ProtobuffToYAML marshals message to YAML. The document has the same shape
+// and field names as the JSON written by ProtobuffToJSON.
+func ProtobuffToYAML(message proto.Message) (string, error) {
+	data, err := ProtobuffToJSON(message)
+	if err != nil {
+		return "", err
+	}
+
+	return jsonToYAML([]byte(data))
+}
+
+// YAMLToProtobuff parses YAML written by ProtobuffToYAML into message
+func YAMLToProtobuff(data string, message proto.Message) error {
+	jsonData, err := yamlToJSON([]byte(data))
+	if err != nil {
+		return err
+	}
+
+	return JSONToProtobuff(string(jsonData), message)
+}
+
+func jsonToYAML(data []byte) (string, error) {
+	var value any
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return "", fmt.Errorf("cannot decode json: %w", err)
+	}
+
+	marshalled, err := yaml.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("cannot marshal yaml: %w", err)
+	}
+
+	return string(marshalled), nil
+}
+
+func yamlToJSON(data []byte) ([]byte, error) {
+	var value any
+	err := yaml.Unmarshal(data, &value)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal yaml: %w", err)
+	}
+
+	marshalled, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode json: %w", err)
+	}
+
+	return marshalled, nil
+}
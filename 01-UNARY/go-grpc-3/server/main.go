@@ -0,0 +1,80 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"grpc-3/pb"
+	"grpc-3/service"
+	"log"
+	"net"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+func main() {
+	port := flag.Int("port", 8080, "the server port")
+	storeKind := flag.String("store", "memory", "laptop store to use: memory or file")
+	storePath := flag.String("store-path", "laptops.log", "laptop log used by the file store")
+	compact := flag.Bool("compact", false, "compact the laptop log before serving")
+	imageFolder := flag.String("image-folder", "img", "folder where uploaded laptop images are saved")
+	maxImageSize := flag.Int("max-image-size", service.DefaultMaxImageSize, "largest laptop image in bytes that can be uploaded")
+	flag.Parse()
+
+	if *maxImageSize <= 0 {
+		log.Fatalf("max-image-size must be positive, got %d", *maxImageSize)
+	}
+
+	laptopStore, err := newLaptopStore(*storeKind, *storePath, *compact)
+	if err != nil {
+		log.Fatalf("cannot create laptop store: %v", err)
+	}
+
+	err = os.MkdirAll(*imageFolder, 0755)
+	if err != nil {
+		log.Fatalf("cannot create image folder: %v", err)
+	}
+	imageStore := service.NewDiskImageStore(*imageFolder)
+	ratingStore := service.NewInMemoryRatingStore()
+
+	laptopServer := service.NewLaptopServer(laptopStore, imageStore, ratingStore)
+	laptopServer.MaxImageSize = *maxImageSize
+
+	grpcServer := grpc.NewServer()
+	pb.RegisterLaptopServiceServer(grpcServer, laptopServer)
+	reflection.Register(grpcServer)
+
+	address := fmt.Sprintf("0.0.0.0:%d", *port)
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		log.Fatalf("cannot start server: %v", err)
+	}
+
+	log.Printf("start server on %s with %s store", address, *storeKind)
+	err = grpcServer.Serve(listener)
+	if err != nil {
+		log.Fatalf("cannot start server: %v", err)
+	}
+}
+
+func newLaptopStore(kind string, path string, compact bool) (service.LaptopStore, error) {
+	switch kind {
+	case "memory":
+		return service.NewInMemoryLaptopStore(), nil
+	case "file":
+		store, err := service.NewFileLaptopStore(path)
+		if err != nil {
+			return nil, err
+		}
+		if compact {
+			err = store.Compact()
+			if err != nil {
+				return nil, err
+			}
+		}
+		return store, nil
+	default:
+		return nil, fmt.Errorf("unknown store %q", kind)
+	}
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// immutableLaptopFields cannot be named in an update mask, id identifies the
+// laptop and updated_at is maintained by the server
+var immutableLaptopFields = map[string]bool{
+	"id":         true,
+	"updated_at": true,
+}
+
+// applyFieldMask copies the fields listed in mask from src into dst. Nested
+// fields are addressed with dots, such as "cpu.max_ghz". A field that is not
+// set in src is cleared in dst. An empty mask copies every mutable field.
+func applyFieldMask(dst proto.Message, src proto.Message, mask *fieldmaskpb.FieldMask) error {
+	paths := mask.GetPaths()
+	if len(paths) == 0 {
+		fields := dst.ProtoReflect().Descriptor().Fields()
+		for i := 0; i < fields.Len(); i++ {
+			name := string(fields.Get(i).Name())
+			if !immutableLaptopFields[name] {
+				paths = append(paths, name)
+			}
+		}
+	}
+
+	for _, path := range paths {
+		if immutableLaptopFields[strings.SplitN(path, ".", 2)[0]] {
+			return fmt.Errorf("field %q cannot be updated", path)
+		}
+	}
+
+	// IsValid checks every path against the message descriptor
+	if !(&fieldmaskpb.FieldMask{Paths: paths}).IsValid(dst) {
+		return fmt.Errorf("invalid update mask: %v", paths)
+	}
+
+	for _, path := range paths {
+		err := copyField(dst.ProtoReflect(), src.ProtoReflect(), strings.Split(path, "."))
+		if err != nil {
+			return fmt.Errorf("cannot apply field %q: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+func copyField(dst protoreflect.Message, src protoreflect.Message, path []string) error {
+	fd := dst.Descriptor().Fields().ByName(protoreflect.Name(path[0]))
+	if fd == nil {
+		return fmt.Errorf("unknown field %s", path[0])
+	}
+
+	if len(path) == 1 {
+		if src.Has(fd) {
+			dst.Set(fd, src.Get(fd))
+		} else {
+			dst.Clear(fd)
+		}
+		return nil
+	}
+
+	if fd.Message() == nil || fd.IsList() || fd.IsMap() {
+		return fmt.Errorf("field %s is not a message", fd.Name())
+	}
+
+	return copyField(dst.Mutable(fd).Message(), src.Get(fd).Message(), path[1:])
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"grpc-3/pb"
+	"grpc-3/serializer"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileLaptopStore stores laptops in an append-only log of length-prefixed
+// pb.LaptopLogEntry records, and keeps an index of them in memory. Updates
+// append the new version of a laptop and deletes append a tombstone, so the
+// last entry for an ID wins when the log is replayed.
+type FileLaptopStore struct {
+	*InMemoryLaptopStore
+
+	mutex sync.Mutex
+	path  string
+	file  *os.File
+}
+
+// NewFileLaptopStore opens the log at path, creating it if needed, and
+// rebuilds the index from the records it contains. An incomplete record at
+// the end of the log, left by a crash in the middle of a write, is dropped.
+func NewFileLaptopStore(path string) (*FileLaptopStore, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open laptop log: %w", err)
+	}
+
+	store := &FileLaptopStore{
+		InMemoryLaptopStore: NewInMemoryLaptopStore(),
+		path:                path,
+		file:                file,
+	}
+
+	err = store.load()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// load replays the log into the in-memory index and leaves the file offset
+// at the end of the last complete record. Everything from the first record
+// that cannot be decoded on is dropped: a torn write only ever damages the
+// end of the log.
+func (store *FileLaptopStore) load() error {
+	reader := bufio.NewReader(store.file)
+	offset := int64(0)
+
+	for {
+		entry := &pb.LaptopLogEntry{}
+		n, err := serializer.ReadDelimitedProtobuff(reader, entry)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Printf("dropping damaged records from offset %d of %s: %v", offset, store.path, err)
+			err = store.file.Truncate(offset)
+			if err != nil {
+				return fmt.Errorf("cannot truncate laptop log: %w", err)
+			}
+			break
+		}
+
+		offset += int64(n)
+		switch e := entry.Entry.(type) {
+		case *pb.LaptopLogEntry_Saved:
+			store.data[e.Saved.Id] = e.Saved
+		case *pb.LaptopLogEntry_DeletedId:
+			delete(store.data, e.DeletedId)
+		}
+	}
+
+	_, err := store.file.Seek(offset, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("cannot seek laptop log: %w", err)
+	}
+	return nil
+}
+
+// Save appends the laptop to the log, then adds it to the index
+func (store *FileLaptopStore) Save(laptop *pb.Laptop) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	found, err := store.InMemoryLaptopStore.Find(laptop.Id)
+	if err != nil {
+		return err
+	}
+	if found != nil {
+		return ErrAlreadyExists
+	}
+
+	err = store.append(savedEntry(laptop))
+	if err != nil {
+		return err
+	}
+
+	return store.InMemoryLaptopStore.Save(laptop)
+}
+
+// Mutate applies fn to a copy of a stored laptop, appends the result to the
+// log, then replaces it in the index
+func (store *FileLaptopStore) Mutate(id string, fn func(laptop *pb.Laptop) error) (*pb.Laptop, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	return store.InMemoryLaptopStore.Mutate(id, func(laptop *pb.Laptop) error {
+		err := fn(laptop)
+		if err != nil {
+			return err
+		}
+		return store.append(savedEntry(laptop))
+	})
+}
+
+// Delete appends a tombstone for the laptop to the log, then removes it from the index
+func (store *FileLaptopStore) Delete(id string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	found, err := store.InMemoryLaptopStore.Find(id)
+	if err != nil {
+		return err
+	}
+	if found == nil {
+		return ErrNotFound
+	}
+
+	err = store.append(&pb.LaptopLogEntry{
+		Entry: &pb.LaptopLogEntry_DeletedId{DeletedId: id},
+	})
+	if err != nil {
+		return err
+	}
+
+	return store.InMemoryLaptopStore.Delete(id)
+}
+
+func savedEntry(laptop *pb.Laptop) *pb.LaptopLogEntry {
+	return &pb.LaptopLogEntry{
+		Entry: &pb.LaptopLogEntry_Saved{Saved: laptop},
+	}
+}
+
+// append writes one entry to the end of the log and flushes it to disk. When
+// either fails, the log is cut back to where it ended, so that a partial
+// record is never followed by the next one.
+func (store *FileLaptopStore) append(entry *pb.LaptopLogEntry) error {
+	offset, err := store.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("cannot find end of laptop log: %w", err)
+	}
+
+	_, err = serializer.WriteDelimitedProtobuff(store.file, entry)
+	if err == nil {
+		err = store.file.Sync()
+		if err != nil {
+			err = fmt.Errorf("cannot sync laptop log: %w", err)
+		}
+	}
+	if err != nil {
+		return errors.Join(err, store.rollback(offset))
+	}
+	return nil
+}
+
+// rollback drops whatever was written to the log after offset
+func (store *FileLaptopStore) rollback(offset int64) error {
+	err := store.file.Truncate(offset)
+	if err != nil {
+		return fmt.Errorf("cannot truncate laptop log: %w", err)
+	}
+	_, err = store.file.Seek(offset, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("cannot seek laptop log: %w", err)
+	}
+	return nil
+}
+
+// Compact rewrites the log so that it holds exactly one record per laptop in
+// the index, dropping superseded versions and tombstones. The new log is written next to the old one and renamed over it,
+// so a crash during compaction leaves the previous log intact.
+func (store *FileLaptopStore) Compact() error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	tmpPath := store.path + ".compact"
+	tmp, err := os.OpenFile(tmpPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("cannot create compacted log: %w", err)
+	}
+
+	err = store.writeAll(tmp)
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+
+	err = os.Rename(tmpPath, store.path)
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("cannot replace laptop log: %w", err)
+	}
+
+	store.file.Close()
+	store.file = tmp
+
+	// the rename only survives a crash once the directory entry is on disk
+	return syncDir(filepath.Dir(store.path))
+}
+
+// syncDir flushes the entries of a directory to disk
+func syncDir(path string) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("cannot open log directory: %w", err)
+	}
+	defer dir.Close()
+
+	err = dir.Sync()
+	if err != nil {
+		return fmt.Errorf("cannot sync log directory: %w", err)
+	}
+	return nil
+}
+
+// writeAll writes every indexed laptop to file and syncs it
+func (store *FileLaptopStore) writeAll(file *os.File) error {
+	store.InMemoryLaptopStore.mutex.RLock()
+	defer store.InMemoryLaptopStore.mutex.RUnlock()
+
+	writer := bufio.NewWriter(file)
+	for _, laptop := range store.data {
+		_, err := serializer.WriteDelimitedProtobuff(writer, savedEntry(laptop))
+		if err != nil {
+			return err
+		}
+	}
+
+	err := writer.Flush()
+	if err != nil {
+		return fmt.Errorf("cannot write compacted log: %w", err)
+	}
+
+	err = file.Sync()
+	if err != nil {
+		return fmt.Errorf("cannot sync compacted log: %w", err)
+	}
+	return nil
+}
+
+// Close closes the underlying log file
+func (store *FileLaptopStore) Close() error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	return store.file.Close()
+}
@@ -0,0 +1,162 @@
+package service_test
+
+import (
+	"grpc-3/pb"
+	"grpc-3/sample"
+	"grpc-3/service"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestFileLaptopStore(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "laptops.log")
+
+	store, err := service.NewFileLaptopStore(path)
+	require.NoError(t, err)
+
+	laptop1 := sample.NewLaptop()
+	laptop2 := sample.NewLaptop()
+	require.NoError(t, store.Save(laptop1))
+	require.NoError(t, store.Save(laptop2))
+	require.ErrorIs(t, store.Save(laptop1), service.ErrAlreadyExists)
+	require.NoError(t, store.Close())
+
+	// reopening rebuilds the index from the log
+	store, err = service.NewFileLaptopStore(path)
+	require.NoError(t, err)
+
+	found, err := store.Find(laptop2.Id)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(laptop2, found))
+	require.ErrorIs(t, store.Save(laptop1), service.ErrAlreadyExists)
+	require.NoError(t, store.Close())
+
+	// a crash in the middle of a write leaves a truncated trailing record
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	require.NoError(t, err)
+	_, err = file.Write([]byte{0, 0, 1, 0, 42, 42})
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	store, err = service.NewFileLaptopStore(path)
+	require.NoError(t, err)
+
+	truncated, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, info.Size(), truncated.Size())
+
+	laptop3 := sample.NewLaptop()
+	require.NoError(t, store.Save(laptop3))
+
+	// updates and deletes are replayed in order
+	laptop1.PriceUsd = 1234
+	_, err = store.Mutate(laptop1.Id, func(laptop *pb.Laptop) error {
+		laptop.PriceUsd = 1234
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, store.Delete(laptop2.Id))
+	require.ErrorIs(t, store.Delete(laptop2.Id), service.ErrNotFound)
+	require.NoError(t, store.Close())
+
+	store, err = service.NewFileLaptopStore(path)
+	require.NoError(t, err)
+
+	found, err = store.Find(laptop2.Id)
+	require.NoError(t, err)
+	require.Nil(t, found)
+
+	beforeCompact, err := os.Stat(path)
+	require.NoError(t, err)
+	require.NoError(t, store.Compact())
+	afterCompact, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Less(t, afterCompact.Size(), beforeCompact.Size())
+
+	// the compacted log is still appendable
+	laptop4 := sample.NewLaptop()
+	require.NoError(t, store.Save(laptop4))
+	require.NoError(t, store.Close())
+
+	store, err = service.NewFileLaptopStore(path)
+	require.NoError(t, err)
+	defer store.Close()
+
+	for _, laptop := range []*pb.Laptop{laptop1, laptop3, laptop4} {
+		found, err := store.Find(laptop.Id)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(laptop, found))
+	}
+
+	found, err = store.Find(laptop2.Id)
+	require.NoError(t, err)
+	require.Nil(t, found)
+}
+
+func TestFileLaptopStoreDamagedTail(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		garbage []byte
+	}{
+		{
+			name:    "huge_length",
+			garbage: []byte{0xff, 0xff, 0xff, 0xff, 1, 2, 3},
+		},
+		{
+			name:    "garbled_body",
+			garbage: []byte{0, 0, 0, 3, 0xff, 0xff, 0xff},
+		},
+		{
+			name:    "torn_length",
+			garbage: []byte{0, 0},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "laptops.log")
+			store, err := service.NewFileLaptopStore(path)
+			require.NoError(t, err)
+			laptop1 := sample.NewLaptop()
+			require.NoError(t, store.Save(laptop1))
+			require.NoError(t, store.Close())
+
+			file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+			require.NoError(t, err)
+			_, err = file.Write(tc.garbage)
+			require.NoError(t, err)
+			require.NoError(t, file.Close())
+
+			// the store reopens without the garbage and keeps accepting appends
+			store, err = service.NewFileLaptopStore(path)
+			require.NoError(t, err)
+			laptop2 := sample.NewLaptop()
+			require.NoError(t, store.Save(laptop2))
+			require.NoError(t, store.Close())
+
+			store, err = service.NewFileLaptopStore(path)
+			require.NoError(t, err)
+			defer store.Close()
+
+			for _, laptop := range []*pb.Laptop{laptop1, laptop2} {
+				found, err := store.Find(laptop.Id)
+				require.NoError(t, err)
+				require.True(t, proto.Equal(laptop, found))
+			}
+		})
+	}
+}
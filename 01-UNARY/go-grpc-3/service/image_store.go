@@ -0,0 +1,77 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidImageType is returned when an image type cannot be a file extension
+var ErrInvalidImageType = errors.New("invalid image type")
+
+// Interface to ImageStore
+type ImageStore interface {
+	// Save saves a new laptop image to the store, returns the image ID and its size
+	Save(laptopID string, imageType string, imageData bytes.Buffer) (string, int, error)
+}
+
+// DiskImageStore stores images on disk, and its info in memory
+type DiskImageStore struct {
+	mutex       sync.RWMutex
+	imageFolder string
+	images      map[string]*ImageInfo
+}
+
+// ImageInfo contains information of the laptop image
+type ImageInfo struct {
+	LaptopID string
+	Type     string
+	Path     string
+	Size     int
+}
+
+// NewDiskImageStore creates new DiskImageStore
+func NewDiskImageStore(imageFolder string) *DiskImageStore {
+	return &DiskImageStore{
+		imageFolder: imageFolder,
+		images:      make(map[string]*ImageInfo),
+	}
+}
+
+// Save adds a new image to a laptop
+func (store *DiskImageStore) Save(laptopID string, imageType string, imageData bytes.Buffer) (string, int, error) {
+	// the type ends the file name, a separator in it would leave the folder
+	if strings.ContainsAny(imageType, `/\`) || strings.ContainsRune(imageType, filepath.Separator) {
+		return "", 0, fmt.Errorf("%w: %q", ErrInvalidImageType, imageType)
+	}
+
+	imageID, err := uuid.NewRandom()
+	if err != nil {
+		return "", 0, fmt.Errorf("cannot generate image id: %w", err)
+	}
+
+	imagePath := filepath.Join(store.imageFolder, imageID.String()+imageType)
+
+	err = os.WriteFile(imagePath, imageData.Bytes(), 0644)
+	if err != nil {
+		return "", 0, fmt.Errorf("cannot write image to file: %w", err)
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.images[imageID.String()] = &ImageInfo{
+		LaptopID: laptopID,
+		Type:     imageType,
+		Path:     imagePath,
+		Size:     imageData.Len(),
+	}
+
+	return imageID.String(), imageData.Len(), nil
+}
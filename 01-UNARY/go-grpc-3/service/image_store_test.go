@@ -0,0 +1,35 @@
+package service_test
+
+import (
+	"bytes"
+	"grpc-3/service"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskImageStoreSave(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	imageFolder := filepath.Join(root, "img")
+	require.NoError(t, os.Mkdir(imageFolder, 0755))
+	store := service.NewDiskImageStore(imageFolder)
+
+	id, size, err := store.Save("laptop-1", ".png", *bytes.NewBufferString("image"))
+	require.NoError(t, err)
+	require.Equal(t, 5, size)
+	require.FileExists(t, filepath.Join(imageFolder, id+".png"))
+
+	for _, imageType := range []string{"/../../x", `\..\x`, "/x.png"} {
+		_, _, err := store.Save("laptop-1", imageType, *bytes.NewBufferString("image"))
+		require.ErrorIs(t, err, service.ErrInvalidImageType, imageType)
+	}
+
+	// nothing was written outside the folder
+	entries, err := os.ReadDir(root)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
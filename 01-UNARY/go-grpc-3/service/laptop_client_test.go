@@ -1,29 +1,393 @@
 package service_test
 
 import (
+	"bytes"
+	"context"
+	"crypto/rand"
 	"grpc-3/pb"
+	"grpc-3/sample"
 	"grpc-3/service"
+	"io"
 	"net"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
 )
 
+const bufSize = 1 << 20
+
 func TestClientCreateLaptop(t *testing.T) {
 	t.Parallel()
+
+	laptopStore := service.NewInMemoryLaptopStore()
+	_, listener := startTestLaptopServer(t, laptopStore, nil, nil)
+	laptopClient := newTestLaptopClient(t, listener)
+
+	laptop := sample.NewLaptop()
+	expectedID := laptop.Id
+
+	req := &pb.CreateLaptopRequest{
+		Laptop: laptop,
+	}
+
+	res, err := laptopClient.CreateLaptop(context.Background(), req)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.Equal(t, expectedID, res.Id)
+
+	// check that the laptop is saved to the store
+	other, err := laptopStore.Find(res.Id)
+	require.NoError(t, err)
+	requireSameLaptop(t, laptop, other)
+}
+
+func TestClientSearchLaptop(t *testing.T) {
+	t.Parallel()
+
+	filter := &pb.Filter{
+		MaxPriceUsd: 2000,
+		MinCpuCores: 4,
+		MinCpuGhz:   2.2,
+		MinRam:      &pb.Memory{Value: 8, Unit: pb.Memory_GIGABYTE},
+	}
+
+	store := service.NewInMemoryLaptopStore()
+	expectedIDs := make(map[string]bool)
+
+	for i := 0; i < 8; i++ {
+		laptop := sample.NewLaptop()
+
+		switch i {
+		case 0:
+			laptop.PriceUsd = 2500
+		case 1:
+			laptop.Cpu.NumberCores = 2
+		case 2:
+			laptop.Cpu.MaxGhz = 2.0
+		case 3:
+			laptop.Ram = &pb.Memory{Value: 4096, Unit: pb.Memory_MEGABYTE}
+		case 4:
+			laptop.PriceUsd = 1999
+			laptop.Cpu.NumberCores = 4
+			laptop.Cpu.MinGhz = 2.5
+			laptop.Cpu.MaxGhz = 4.5
+			laptop.Ram = &pb.Memory{Value: 16, Unit: pb.Memory_GIGABYTE}
+			expectedIDs[laptop.Id] = true
+		case 5:
+			laptop.PriceUsd = 2000
+			laptop.Cpu.NumberCores = 6
+			laptop.Cpu.MinGhz = 2.8
+			laptop.Cpu.MaxGhz = 5.0
+			laptop.Ram = &pb.Memory{Value: 64 << 30, Unit: pb.Memory_BIT}
+			expectedIDs[laptop.Id] = true
+		case 6:
+			laptop.PriceUsd = 1500
+			laptop.Cpu.NumberCores = 8
+			laptop.Cpu.MinGhz = 2.2
+			laptop.Cpu.MaxGhz = 3.0
+			laptop.Ram = &pb.Memory{Value: 1, Unit: pb.Memory_TERABYTE}
+			expectedIDs[laptop.Id] = true
+		case 7:
+			laptop.PriceUsd = 1500
+			laptop.Cpu.NumberCores = 8
+			laptop.Cpu.MinGhz = 2.2
+			laptop.Cpu.MaxGhz = 3.0
+			laptop.Ram = &pb.Memory{Value: 64, Unit: pb.Memory_UNKNOWN}
+		}
+
+		err := store.Save(laptop)
+		require.NoError(t, err)
+	}
+
+	_, listener := startTestLaptopServer(t, store, nil, nil)
+	laptopClient := newTestLaptopClient(t, listener)
+
+	req := &pb.SearchLaptopRequest{Filter: filter}
+	stream, err := laptopClient.SearchLaptop(context.Background(), req)
+	require.NoError(t, err)
+
+	found := 0
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		require.NoError(t, err)
+		require.Contains(t, expectedIDs, res.GetLaptop().GetId())
+
+		found += 1
+	}
+
+	require.Equal(t, len(expectedIDs), found)
+
+	unknownUnit := &pb.Filter{MinRam: &pb.Memory{Value: 8, Unit: pb.Memory_UNKNOWN}}
+	stream, err = laptopClient.SearchLaptop(context.Background(), &pb.SearchLaptopRequest{Filter: unknownUnit})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+}
+
+func TestClientUploadImage(t *testing.T) {
+	t.Parallel()
+
+	imageFolder := t.TempDir()
+
+	laptopStore := service.NewInMemoryLaptopStore()
+	imageStore := service.NewDiskImageStore(imageFolder)
+
+	laptop := sample.NewLaptop()
+	err := laptopStore.Save(laptop)
+	require.NoError(t, err)
+
+	laptopServer, listener := startTestLaptopServer(t, laptopStore, imageStore, nil)
+	laptopServer.MaxImageSize = 4 << 10
+	laptopClient := newTestLaptopClient(t, listener)
+
+	testCases := []struct {
+		name      string
+		laptopID  string
+		imageType string
+		size      int
+		code      codes.Code
+	}{
+		{
+			name:     "success",
+			laptopID: laptop.Id,
+			size:     3 << 10,
+			code:     codes.OK,
+		},
+		{
+			name:      "success_png",
+			laptopID:  laptop.Id,
+			imageType: ".png",
+			size:      1 << 10,
+			code:      codes.OK,
+		},
+		{
+			name:      "failed_path_in_type",
+			laptopID:  laptop.Id,
+			imageType: "/../../x",
+			size:      1 << 10,
+			code:      codes.InvalidArgument,
+		},
+		{
+			name:      "failed_unknown_type",
+			laptopID:  laptop.Id,
+			imageType: ".gif",
+			size:      1 << 10,
+			code:      codes.InvalidArgument,
+		},
+		{
+			name:     "failed_unknown_laptop",
+			laptopID: sample.NewLaptop().Id,
+			size:     1 << 10,
+			code:     codes.NotFound,
+		},
+		{
+			name:     "failed_too_large",
+			laptopID: laptop.Id,
+			size:     5 << 10,
+			code:     codes.InvalidArgument,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		if tc.imageType == "" {
+			tc.imageType = ".jpg"
+		}
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			imageData := make([]byte, tc.size)
+			_, err := rand.Read(imageData)
+			require.NoError(t, err)
+
+			res, err := uploadTestImage(laptopClient, tc.laptopID, tc.imageType, imageData)
+			if tc.code == codes.OK {
+				require.NoError(t, err)
+				require.NotEmpty(t, res.GetId())
+				require.EqualValues(t, tc.size, res.GetSize())
+
+				saved, err := os.ReadFile(filepath.Join(imageFolder, res.GetId()+tc.imageType))
+				require.NoError(t, err)
+				require.Equal(t, imageData, saved)
+			} else {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tc.code, st.Code())
+			}
+		})
+	}
 }
 
-func startTestLaptopServer(t *testing.T) (*service.LaptopServer, string) {
-	laptopServer := service.NewLaptopServer(service.NewInMemoryLaptopStore())
+func uploadTestImage(laptopClient pb.LaptopServiceClient, laptopID string, imageType string, imageData []byte) (*pb.UploadImageResponse, error) {
+	stream, err := laptopClient.UploadImage(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	req := &pb.UploadImageRequest{
+		Data: &pb.UploadImageRequest_Info{
+			Info: &pb.ImageInfo{
+				LaptopId:  laptopID,
+				ImageType: imageType,
+			},
+		},
+	}
+	err = stream.Send(req)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := bytes.NewReader(imageData)
+	buffer := make([]byte, 1024)
+
+	for {
+		n, err := reader.Read(buffer)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		req := &pb.UploadImageRequest{
+			Data: &pb.UploadImageRequest_ChunkData{
+				ChunkData: buffer[:n],
+			},
+		}
+
+		// the server may have closed the stream already, its status is
+		// returned by CloseAndRecv
+		if err := stream.Send(req); err != nil {
+			break
+		}
+	}
+
+	return stream.CloseAndRecv()
+}
+
+func TestClientRateLaptop(t *testing.T) {
+	t.Parallel()
+
+	laptopStore := service.NewInMemoryLaptopStore()
+	ratingStore := service.NewInMemoryRatingStore()
+
+	laptop := sample.NewLaptop()
+	err := laptopStore.Save(laptop)
+	require.NoError(t, err)
+
+	_, listener := startTestLaptopServer(t, laptopStore, nil, ratingStore)
+	laptopClient := newTestLaptopClient(t, listener)
+
+	stream, err := laptopClient.RateLaptop(context.Background())
+	require.NoError(t, err)
+
+	scores := []float64{8, 7.5, 10}
+	averages := []float64{8, 7.75, 8.5}
+
+	n := len(scores)
+	for i := 0; i < n; i++ {
+		req := &pb.RateLaptopRequest{
+			LaptopId: laptop.Id,
+			Score:    scores[i],
+		}
+
+		err := stream.Send(req)
+		require.NoError(t, err)
+	}
+
+	err = stream.CloseSend()
+	require.NoError(t, err)
+
+	for idx := 0; ; idx++ {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			require.Equal(t, n, idx)
+			break
+		}
+
+		require.NoError(t, err)
+		require.Equal(t, laptop.Id, res.GetLaptopId())
+		require.Equal(t, uint32(idx+1), res.GetRatedCount())
+		require.Equal(t, averages[idx], res.GetAverageScore())
+	}
+
+	unknown, err := laptopClient.RateLaptop(context.Background())
+	require.NoError(t, err)
+
+	err = unknown.Send(&pb.RateLaptopRequest{LaptopId: sample.NewLaptop().Id, Score: 5})
+	require.NoError(t, err)
+
+	_, err = unknown.Recv()
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.NotFound, st.Code())
+}
+
+// startTestLaptopServer serves LaptopService over an in-process bufconn
+// listener. The server is stopped gracefully when the test finishes.
+func startTestLaptopServer(t *testing.T, laptopStore service.LaptopStore, imageStore service.ImageStore, ratingStore service.RatingStore) (*service.LaptopServer, *bufconn.Listener) {
+	laptopServer := service.NewLaptopServer(laptopStore, imageStore, ratingStore)
 
 	grpcServer := grpc.NewServer()
 	pb.RegisterLaptopServiceServer(grpcServer, laptopServer)
 
-	listener, err := net.Listen("tcp", ":0")
+	listener := bufconn.Listen(bufSize)
+
+	served := make(chan error, 1)
+	go func() {
+		served <- grpcServer.Serve(listener)
+	}()
+
+	t.Cleanup(func() {
+		grpcServer.GracefulStop()
+		require.NoError(t, <-served)
+	})
+
+	return laptopServer, listener
+}
+
+// newTestLaptopClient connects a LaptopService client to the listener of a
+// test server. The connection is closed when the test finishes, before the
+// server is stopped.
+func newTestLaptopClient(t *testing.T, listener *bufconn.Listener) pb.LaptopServiceClient {
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
 	require.NoError(t, err)
 
-	go grpcServer.Serve(listener)
+	t.Cleanup(func() {
+		conn.Close()
+	})
 
-	return laptopServer, listener.Addr().String()
-}
\ No newline at end of file
+	return pb.NewLaptopServiceClient(conn)
+}
+
+// requireSameLaptop asserts that two laptops are proto-equal
+func requireSameLaptop(t *testing.T, expected *pb.Laptop, actual *pb.Laptop) {
+	t.Helper()
+
+	require.NotNil(t, actual)
+	require.True(t, proto.Equal(expected, actual), "expected %v, got %v", expected, actual)
+}
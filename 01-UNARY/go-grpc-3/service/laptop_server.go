@@ -1,25 +1,48 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"grpc-3/pb"
+	"grpc-3/validator"
+	"io"
 	"log"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 )
 
+// DefaultMaxImageSize is the upload limit used unless MaxImageSize is changed
+const DefaultMaxImageSize = 1 << 20
+
+// imageTypes are the image types UploadImage accepts
+var imageTypes = map[string]bool{
+	".jpg":  true,
+	".jpeg": true,
+	".png":  true,
+}
+
 type LaptopServer struct {
-	Store LaptopStore
+	pb.UnimplementedLaptopServiceServer
+	Store       LaptopStore
+	ImageStore  ImageStore
+	RatingStore RatingStore
+	// MaxImageSize is the largest image in bytes that UploadImage accepts
+	MaxImageSize int
 }
 
-func NewLaptopServer(store LaptopStore) *LaptopServer{
+func NewLaptopServer(store LaptopStore, imageStore ImageStore, ratingStore RatingStore) *LaptopServer{
 	// return &LaptopServer{store}
 	return &LaptopServer{
-		Store: store,
+		Store:        store,
+		ImageStore:   imageStore,
+		RatingStore:  ratingStore,
+		MaxImageSize: DefaultMaxImageSize,
 	}
 }
 
@@ -41,6 +64,10 @@ func (server *LaptopServer)CreateLaptop(ctx context.Context, req *pb.CreateLapto
 
 	}
 
+	if badRequest := validator.Laptop(laptop); badRequest != nil {
+		return nil, invalidLaptopError(badRequest)
+	}
+
 	// TODO : saave laptop to database
 	err := server.Store.Save(laptop)
 	if err != nil {
@@ -56,3 +83,275 @@ func (server *LaptopServer)CreateLaptop(ctx context.Context, req *pb.CreateLapto
 	}
 	return res, nil
 }
+
+// GetLaptop is a unary RPC to find a laptop by ID
+func (server *LaptopServer) GetLaptop(ctx context.Context, req *pb.GetLaptopRequest) (*pb.GetLaptopResponse, error) {
+	log.Printf("receive a get laptop request with id: %s", req.GetId())
+
+	laptop, err := server.Store.Find(req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot find laptop: %v", err)
+	}
+	if laptop == nil {
+		return nil, status.Errorf(codes.NotFound, "laptop id %s is not found", req.GetId())
+	}
+
+	res := &pb.GetLaptopResponse{
+		Laptop: laptop,
+	}
+	return res, nil
+}
+
+// UpdateLaptop is a unary RPC to change the fields of a laptop listed in the update mask
+func (server *LaptopServer) UpdateLaptop(ctx context.Context, req *pb.UpdateLaptopRequest) (*pb.UpdateLaptopResponse, error) {
+	update := req.GetLaptop()
+	log.Printf("receive an update laptop request with id: %s, mask: %v", update.GetId(), req.GetUpdateMask().GetPaths())
+
+	if update == nil {
+		return nil, status.Error(codes.InvalidArgument, "laptop is required")
+	}
+
+	// the mask is applied to the stored laptop while the store holds it, so
+	// concurrent updates of different fields do not undo each other
+	laptop, err := server.Store.Mutate(update.GetId(), func(laptop *pb.Laptop) error {
+		err := applyFieldMask(laptop, update, req.GetUpdateMask())
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "cannot apply update mask: %v", err)
+		}
+		laptop.UpdatedAt = timestamppb.Now()
+
+		if badRequest := validator.Laptop(laptop); badRequest != nil {
+			return invalidLaptopError(badRequest)
+		}
+		return nil
+	})
+	if errors.Is(err, ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "laptop id %s is not found", update.GetId())
+	}
+	if err != nil {
+		// errors returned by the mutation already are a gRPC status
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "cannot update laptop in the store: %v", err)
+	}
+
+	log.Printf("laptop updated with id: %s", laptop.Id)
+	res := &pb.UpdateLaptopResponse{
+		Laptop: laptop,
+	}
+	return res, nil
+}
+
+// DeleteLaptop is a unary RPC to remove a laptop by ID
+func (server *LaptopServer) DeleteLaptop(ctx context.Context, req *pb.DeleteLaptopRequest) (*pb.DeleteLaptopResponse, error) {
+	log.Printf("receive a delete laptop request with id: %s", req.GetId())
+
+	err := server.Store.Delete(req.GetId())
+	if err != nil {
+		code := codes.Internal
+		if errors.Is(err, ErrNotFound) {
+			code = codes.NotFound
+		}
+		return nil, status.Errorf(code, "cannot delete laptop from the store: %v", err)
+	}
+
+	log.Printf("laptop deleted with id: %s", req.GetId())
+	return &pb.DeleteLaptopResponse{}, nil
+}
+
+// SearchLaptop is a server-streaming RPC to search for laptops
+func (server *LaptopServer) SearchLaptop(req *pb.SearchLaptopRequest, stream pb.LaptopService_SearchLaptopServer) error {
+	filter := req.GetFilter()
+	log.Printf("receive a search-laptop request with filter: %v", filter)
+
+	err := validateFilter(filter)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
+	}
+
+	err = server.Store.Search(
+		stream.Context(),
+		filter,
+		func(laptop *pb.Laptop) error {
+			res := &pb.SearchLaptopResponse{Laptop: laptop}
+			err := stream.Send(res)
+			if err != nil {
+				return err
+			}
+
+			log.Printf("sent laptop with id: %s", laptop.GetId())
+			return nil
+		},
+	)
+	if err != nil {
+		return status.Errorf(codeFromError(err), "unexpected error: %v", err)
+	}
+
+	return nil
+}
+
+// UploadImage is a client-streaming RPC to upload a laptop image
+func (server *LaptopServer) UploadImage(stream pb.LaptopService_UploadImageServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return logError(status.Errorf(codes.Unknown, "cannot receive image info: %v", err))
+	}
+
+	laptopID := req.GetInfo().GetLaptopId()
+	imageType := req.GetInfo().GetImageType()
+	log.Printf("receive an upload-image request for laptop %s with image type %s", laptopID, imageType)
+
+	if !imageTypes[imageType] {
+		return logError(status.Errorf(codes.InvalidArgument, "image type %q is not one of .jpg, .jpeg or .png", imageType))
+	}
+
+	laptop, err := server.Store.Find(laptopID)
+	if err != nil {
+		return logError(status.Errorf(codes.Internal, "cannot find laptop: %v", err))
+	}
+	if laptop == nil {
+		return logError(status.Errorf(codes.NotFound, "laptop id %s doesn't exist", laptopID))
+	}
+
+	imageData := bytes.Buffer{}
+	imageSize := 0
+
+	for {
+		if err := contextError(stream.Context()); err != nil {
+			return logError(err)
+		}
+
+		log.Print("waiting to receive more data")
+
+		req, err := stream.Recv()
+		if err == io.EOF {
+			log.Print("no more data")
+			break
+		}
+		if err != nil {
+			return logError(status.Errorf(codes.Unknown, "cannot receive chunk data: %v", err))
+		}
+
+		chunk := req.GetChunkData()
+		size := len(chunk)
+
+		log.Printf("received a chunk with size: %d", size)
+
+		imageSize += size
+		if imageSize > server.MaxImageSize {
+			return logError(status.Errorf(codes.InvalidArgument, "image is too large: %d > %d", imageSize, server.MaxImageSize))
+		}
+
+		_, err = imageData.Write(chunk)
+		if err != nil {
+			return logError(status.Errorf(codes.Internal, "cannot write chunk data: %v", err))
+		}
+	}
+
+	imageID, size, err := server.ImageStore.Save(laptopID, imageType, imageData)
+	if err != nil {
+		return logError(status.Errorf(codes.Internal, "cannot save image to the store: %v", err))
+	}
+
+	res := &pb.UploadImageResponse{
+		Id:   imageID,
+		Size: uint32(size),
+	}
+
+	err = stream.SendAndClose(res)
+	if err != nil {
+		return logError(status.Errorf(codes.Unknown, "cannot send response: %v", err))
+	}
+
+	log.Printf("saved image with id: %s, size: %d", imageID, size)
+	return nil
+}
+
+// RateLaptop is a bidirectional-streaming RPC that allows client to rate a stream of laptops
+// with a score, and returns a stream of average score for each of them
+func (server *LaptopServer) RateLaptop(stream pb.LaptopService_RateLaptopServer) error {
+	for {
+		err := contextError(stream.Context())
+		if err != nil {
+			return err
+		}
+
+		req, err := stream.Recv()
+		if err == io.EOF {
+			log.Print("no more data")
+			break
+		}
+		if err != nil {
+			return logError(status.Errorf(codes.Unknown, "cannot receive stream request: %v", err))
+		}
+
+		laptopID := req.GetLaptopId()
+		score := req.GetScore()
+
+		log.Printf("received a rate-laptop request: id = %s, score = %.2f", laptopID, score)
+
+		found, err := server.Store.Find(laptopID)
+		if err != nil {
+			return logError(status.Errorf(codes.Internal, "cannot find laptop: %v", err))
+		}
+		if found == nil {
+			return logError(status.Errorf(codes.NotFound, "laptop id %s is not found", laptopID))
+		}
+
+		rating, err := server.RatingStore.Add(laptopID, score)
+		if err != nil {
+			return logError(status.Errorf(codes.Internal, "cannot add rating to the store: %v", err))
+		}
+
+		res := &pb.RateLaptopResponse{
+			LaptopId:     laptopID,
+			RatedCount:   rating.Count,
+			AverageScore: rating.Sum / float64(rating.Count),
+		}
+
+		err = stream.Send(res)
+		if err != nil {
+			return logError(status.Errorf(codes.Unknown, "cannot send stream response: %v", err))
+		}
+	}
+
+	return nil
+}
+
+// invalidLaptopError builds an InvalidArgument status carrying the field violations
+func invalidLaptopError(badRequest *errdetails.BadRequest) error {
+	st := status.Newf(codes.InvalidArgument, "invalid laptop: %d field violation(s)", len(badRequest.GetFieldViolations()))
+	detailed, err := st.WithDetails(badRequest)
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+func logError(err error) error {
+	if err != nil {
+		log.Print(err)
+	}
+	return err
+}
+
+// contextError maps a finished context to the matching gRPC status error
+func contextError(ctx context.Context) error {
+	switch ctx.Err() {
+	case context.Canceled:
+		return status.Error(codes.Canceled, "request is canceled")
+	case context.DeadlineExceeded:
+		return status.Error(codes.DeadlineExceeded, "deadline is exceeded")
+	default:
+		return nil
+	}
+}
+
+// codeFromError keeps the code of an error that already carries a gRPC status
+func codeFromError(err error) codes.Code {
+	if st, ok := status.FromError(err); ok {
+		return st.Code()
+	}
+	return codes.Internal
+}
@@ -5,11 +5,16 @@ import (
 	"grpc-3/pb"
 	"grpc-3/sample"
 	"grpc-3/service"
+	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 func TestServerCreateLaptop(t *testing.T)  {
@@ -21,6 +26,10 @@ func TestServerCreateLaptop(t *testing.T)  {
 	laptopInvalidID := sample.NewLaptop()
 	laptopInvalidID.Id = "invalid-uuid"
 
+	laptopInvalidCPU := sample.NewLaptop()
+	laptopInvalidCPU.Cpu.MaxGhz = laptopInvalidCPU.Cpu.MinGhz - 1
+	laptopInvalidCPU.PriceUsd = -1
+
 	laptopDuplicateID := sample.NewLaptop()
 	storeDuplicateID := service.NewInMemoryLaptopStore()
 	err := storeDuplicateID.Save(laptopDuplicateID)
@@ -50,6 +59,12 @@ func TestServerCreateLaptop(t *testing.T)  {
 			store : service.NewInMemoryLaptopStore(),
 			code : codes.InvalidArgument,
 		},
+		{
+			name: "failed_invalid_laptop",
+			laptop: laptopInvalidCPU,
+			store : service.NewInMemoryLaptopStore(),
+			code : codes.InvalidArgument,
+		},
 		{
 			name: "failed_duplicate_id",
 			laptop: laptopDuplicateID,
@@ -67,7 +82,7 @@ func TestServerCreateLaptop(t *testing.T)  {
 				Laptop: tc.laptop,
 			}
 
-			server := service.NewLaptopServer(tc.store)
+			server := service.NewLaptopServer(tc.store, nil, nil)
 			res, err := server.CreateLaptop(context.Background(), req)
 			if tc.code == codes.OK {
 				require.NoError(t, err)
@@ -85,4 +100,313 @@ func TestServerCreateLaptop(t *testing.T)  {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestServerCreateLaptopViolations(t *testing.T) {
+	t.Parallel()
+
+	laptop := sample.NewLaptop()
+	laptop.Cpu.MaxGhz = laptop.Cpu.MinGhz - 1
+	laptop.Cpu.NumberThreads = laptop.Cpu.NumberCores - 1
+	laptop.PriceUsd = -1
+
+	store := service.NewInMemoryLaptopStore()
+	server := service.NewLaptopServer(store, nil, nil)
+
+	res, err := server.CreateLaptop(context.Background(), &pb.CreateLaptopRequest{Laptop: laptop})
+	require.Error(t, err)
+	require.Nil(t, res)
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 1)
+
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+
+	fields := []string{}
+	for _, violation := range badRequest.GetFieldViolations() {
+		fields = append(fields, violation.GetField())
+	}
+	require.ElementsMatch(t, []string{"cpu.max_ghz", "cpu.number_threads", "price_usd"}, fields)
+
+	// validation runs before the store is touched
+	found, err := store.Find(laptop.Id)
+	require.NoError(t, err)
+	require.Nil(t, found)
+}
+
+func TestServerGetLaptop(t *testing.T) {
+	t.Parallel()
+
+	laptop := sample.NewLaptop()
+	store := service.NewInMemoryLaptopStore()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name string
+		id   string
+		code codes.Code
+	}{
+		{
+			name: "success",
+			id:   laptop.Id,
+			code: codes.OK,
+		},
+		{
+			name: "failed_not_found",
+			id:   sample.NewLaptop().Id,
+			code: codes.NotFound,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			req := &pb.GetLaptopRequest{
+				Id: tc.id,
+			}
+
+			server := service.NewLaptopServer(store, nil, nil)
+			res, err := server.GetLaptop(context.Background(), req)
+			if tc.code == codes.OK {
+				require.NoError(t, err)
+				require.True(t, proto.Equal(laptop, res.GetLaptop()))
+			} else {
+				require.Error(t, err)
+				require.Nil(t, res)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tc.code, st.Code())
+			}
+		})
+	}
+}
+
+func TestServerUpdateLaptop(t *testing.T) {
+	t.Parallel()
+
+	update := sample.NewLaptop()
+	update.PriceUsd = 999
+	update.Ram = &pb.Memory{Value: 32, Unit: pb.Memory_GIGABYTE}
+	update.Cpu.MaxGhz = 5.0
+	update.Cpu.NumberThreads = 0
+
+	testCases := []struct {
+		name  string
+		id    string
+		paths []string
+		code  codes.Code
+	}{
+		{
+			name:  "success_price",
+			paths: []string{"price_usd"},
+			code:  codes.OK,
+		},
+		{
+			name:  "success_price_and_ram",
+			paths: []string{"price_usd", "ram"},
+			code:  codes.OK,
+		},
+		{
+			name:  "success_nested_field",
+			paths: []string{"cpu.max_ghz"},
+			code:  codes.OK,
+		},
+		{
+			name:  "failed_unknown_field",
+			paths: []string{"colour"},
+			code:  codes.InvalidArgument,
+		},
+		{
+			name:  "failed_immutable_field",
+			paths: []string{"id"},
+			code:  codes.InvalidArgument,
+		},
+		{
+			name:  "failed_invalid_value",
+			paths: []string{"cpu.number_threads"},
+			code:  codes.InvalidArgument,
+		},
+		{
+			name:  "failed_not_found",
+			id:    sample.NewLaptop().Id,
+			paths: []string{"price_usd"},
+			code:  codes.NotFound,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			laptop := sample.NewLaptop()
+			store := service.NewInMemoryLaptopStore()
+			err := store.Save(laptop)
+			require.NoError(t, err)
+
+			other := proto.Clone(update).(*pb.Laptop)
+			other.Id = laptop.Id
+			if len(tc.id) > 0 {
+				other.Id = tc.id
+			}
+
+			req := &pb.UpdateLaptopRequest{
+				Laptop:     other,
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: tc.paths},
+			}
+
+			server := service.NewLaptopServer(store, nil, nil)
+			res, err := server.UpdateLaptop(context.Background(), req)
+			if tc.code == codes.OK {
+				require.NoError(t, err)
+				updated := res.GetLaptop()
+				require.True(t, updated.GetUpdatedAt().AsTime().After(laptop.GetUpdatedAt().AsTime()))
+
+				// only the listed fields change
+				expected := proto.Clone(laptop).(*pb.Laptop)
+				for _, path := range tc.paths {
+					switch path {
+					case "price_usd":
+						expected.PriceUsd = update.PriceUsd
+					case "ram":
+						expected.Ram = update.Ram
+					case "cpu.max_ghz":
+						expected.Cpu.MaxGhz = update.Cpu.MaxGhz
+					}
+				}
+				expected.UpdatedAt = updated.UpdatedAt
+				require.True(t, proto.Equal(expected, updated))
+
+				stored, err := store.Find(laptop.Id)
+				require.NoError(t, err)
+				require.True(t, proto.Equal(updated, stored))
+			} else {
+				require.Error(t, err)
+				require.Nil(t, res)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tc.code, st.Code())
+			}
+		})
+	}
+}
+
+func TestServerUpdateLaptopConcurrent(t *testing.T) {
+	t.Parallel()
+
+	fileStore, err := service.NewFileLaptopStore(filepath.Join(t.TempDir(), "laptops.log"))
+	require.NoError(t, err)
+	t.Cleanup(func() { fileStore.Close() })
+
+	stores := map[string]service.LaptopStore{
+		"memory": service.NewInMemoryLaptopStore(),
+		"file":   fileStore,
+	}
+
+	for name, store := range stores {
+		store := store
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := service.NewLaptopServer(store, nil, nil)
+			update := sample.NewLaptop()
+			update.PriceUsd = 999
+			update.Ram = &pb.Memory{Value: 32, Unit: pb.Memory_GIGABYTE}
+			update.Cpu.MaxGhz = 5.0
+			update.Weight = &pb.Laptop_WeightKg{WeightKg: 1.2}
+			paths := []string{"price_usd", "ram", "cpu.max_ghz", "weight_kg"}
+
+			// updates of different fields made at the same time all persist
+			for round := 0; round < 50; round++ {
+				laptop := sample.NewLaptop()
+				require.NoError(t, store.Save(laptop))
+
+				var wg sync.WaitGroup
+				for _, path := range paths {
+					wg.Add(1)
+					go func(path string) {
+						defer wg.Done()
+
+						other := proto.Clone(update).(*pb.Laptop)
+						other.Id = laptop.Id
+						_, err := server.UpdateLaptop(context.Background(), &pb.UpdateLaptopRequest{
+							Laptop:     other,
+							UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{path}},
+						})
+						require.NoError(t, err)
+					}(path)
+				}
+				wg.Wait()
+
+				stored, err := store.Find(laptop.Id)
+				require.NoError(t, err)
+				require.Equal(t, update.PriceUsd, stored.PriceUsd)
+				require.True(t, proto.Equal(update.Ram, stored.Ram))
+				require.Equal(t, update.Cpu.MaxGhz, stored.Cpu.MaxGhz)
+				require.Equal(t, update.GetWeightKg(), stored.GetWeightKg())
+			}
+		})
+	}
+}
+
+func TestServerDeleteLaptop(t *testing.T) {
+	t.Parallel()
+
+	laptop := sample.NewLaptop()
+	store := service.NewInMemoryLaptopStore()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name string
+		id   string
+		code codes.Code
+	}{
+		{
+			name: "success",
+			id:   laptop.Id,
+			code: codes.OK,
+		},
+		{
+			name: "failed_not_found",
+			id:   sample.NewLaptop().Id,
+			code: codes.NotFound,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			req := &pb.DeleteLaptopRequest{
+				Id: tc.id,
+			}
+
+			server := service.NewLaptopServer(store, nil, nil)
+			res, err := server.DeleteLaptop(context.Background(), req)
+			if tc.code == codes.OK {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+
+				found, err := store.Find(tc.id)
+				require.NoError(t, err)
+				require.Nil(t, found)
+			} else {
+				require.Error(t, err)
+				require.Nil(t, res)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tc.code, st.Code())
+			}
+		})
+	}
+}
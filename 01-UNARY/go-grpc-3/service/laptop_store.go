@@ -1,9 +1,12 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"grpc-3/pb"
+	"log"
+	"math/bits"
 	"sync"
 
 	"github.com/jinzhu/copier"
@@ -11,10 +14,21 @@ import (
 
 // Define error
 var ErrAlreadyExists = errors.New("record already exists")
+var ErrNotFound = errors.New("record not found")
+var ErrUnknownUnit = errors.New("unknown memory unit")
 
 // Interface to LaptopStore
 type LaptopStore interface {
 	Save (laptop *pb.Laptop) error
+	// Find finds a laptop by ID, returns nil if it does not exist
+	Find(id string) (*pb.Laptop, error)
+	// Mutate applies fn to a copy of a stored laptop and stores the result in one
+	// atomic step, returns ErrNotFound if it does not exist. Nothing is stored when fn fails.
+	Mutate(id string, fn func(laptop *pb.Laptop) error) (*pb.Laptop, error)
+	// Delete removes a laptop by ID, returns ErrNotFound if it does not exist
+	Delete(id string) error
+	// Search searches for laptops with filter, returns one by one via the found function
+	Search(ctx context.Context, filter *pb.Filter, found func(laptop *pb.Laptop) error) error
 }
 
 // InMemoryLaptopStore stores laptops in memory
@@ -40,12 +54,213 @@ func (store *InMemoryLaptopStore) Save(laptop *pb.Laptop) error {
 	}
 
 	// deep copy
-	other := &pb.Laptop{}
-	err := copier.Copy(other, laptop)
+	other, err := deepCopy(laptop)
 	if err != nil {
-		return fmt.Errorf("cannot copy laptop data: %v", err)
+		return err
 	}
 
 	store.data[other.Id] = other
 	return nil
-}
\ No newline at end of file
+}
+
+// Find finds a laptop by ID
+func (store *InMemoryLaptopStore) Find(id string) (*pb.Laptop, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	laptop := store.data[id]
+	if laptop == nil {
+		return nil, nil
+	}
+
+	return deepCopy(laptop)
+}
+
+// Mutate applies fn to a copy of a stored laptop and stores the result
+func (store *InMemoryLaptopStore) Mutate(id string, fn func(laptop *pb.Laptop) error) (*pb.Laptop, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	stored := store.data[id]
+	if stored == nil {
+		return nil, ErrNotFound
+	}
+
+	laptop, err := deepCopy(stored)
+	if err != nil {
+		return nil, err
+	}
+	err = fn(laptop)
+	if err != nil {
+		return nil, err
+	}
+
+	// deep copy
+	other, err := deepCopy(laptop)
+	if err != nil {
+		return nil, err
+	}
+
+	store.data[id] = other
+	return laptop, nil
+}
+
+// Delete removes a laptop by ID
+func (store *InMemoryLaptopStore) Delete(id string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.data[id] == nil {
+		return ErrNotFound
+	}
+
+	delete(store.data, id)
+	return nil
+}
+
+// Search searches for laptops with filter, returns one by one via the found function.
+// The matches are copied under the lock and sent after it is released, so a
+// slow receiver never holds up writers.
+func (store *InMemoryLaptopStore) Search(
+	ctx context.Context,
+	filter *pb.Filter,
+	found func(laptop *pb.Laptop) error,
+) error {
+	matches, err := store.match(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	for _, laptop := range matches {
+		if err := contextError(ctx); err != nil {
+			log.Print("context is cancelled")
+			return err
+		}
+
+		err := found(laptop)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// match returns a copy of every laptop that passes filter
+func (store *InMemoryLaptopStore) match(ctx context.Context, filter *pb.Filter) ([]*pb.Laptop, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	matches := []*pb.Laptop{}
+	for _, laptop := range store.data {
+		if err := contextError(ctx); err != nil {
+			log.Print("context is cancelled")
+			return nil, err
+		}
+
+		if isQualified(filter, laptop) {
+			// deep copy
+			other, err := deepCopy(laptop)
+			if err != nil {
+				return nil, err
+			}
+			matches = append(matches, other)
+		}
+	}
+
+	return matches, nil
+}
+
+// isQualified reports whether laptop passes filter. A laptop whose RAM cannot
+// be compared with the filter's min_ram, because either unit is unknown, does
+// not match.
+func isQualified(filter *pb.Filter, laptop *pb.Laptop) bool {
+	if filter.GetMaxPriceUsd() > 0 && laptop.GetPriceUsd() > filter.GetMaxPriceUsd() {
+		return false
+	}
+
+	if laptop.GetCpu().GetNumberCores() < filter.GetMinCpuCores() {
+		return false
+	}
+
+	if laptop.GetCpu().GetMaxGhz() < filter.GetMinCpuGhz() {
+		return false
+	}
+
+	if filter.GetMinRam().GetValue() > 0 {
+		result, err := compareMemory(laptop.GetRam(), filter.GetMinRam())
+		if err != nil || result < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validateFilter checks the parts of filter that Search cannot recover from,
+// it fails with ErrUnknownUnit when min_ram is set without a known unit
+func validateFilter(filter *pb.Filter) error {
+	if filter.GetMinRam().GetValue() > 0 {
+		if _, err := bitsPerUnit(filter.GetMinRam().GetUnit()); err != nil {
+			return fmt.Errorf("min_ram: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// compareMemory returns -1, 0 or +1 depending on whether a is smaller than,
+// equal to or larger than b. Both values are scaled to bits with 128-bit
+// arithmetic so that large values in big units never overflow.
+func compareMemory(a *pb.Memory, b *pb.Memory) (int, error) {
+	aUnit, err := bitsPerUnit(a.GetUnit())
+	if err != nil {
+		return 0, err
+	}
+	bUnit, err := bitsPerUnit(b.GetUnit())
+	if err != nil {
+		return 0, err
+	}
+
+	aHi, aLo := bits.Mul64(a.GetValue(), aUnit)
+	bHi, bLo := bits.Mul64(b.GetValue(), bUnit)
+
+	switch {
+	case aHi < bHi, aHi == bHi && aLo < bLo:
+		return -1, nil
+	case aHi > bHi, aHi == bHi && aLo > bLo:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// bitsPerUnit returns how many bits one unit of memory holds, it fails with
+// ErrUnknownUnit when the unit is not set
+func bitsPerUnit(unit pb.Memory_Unit) (uint64, error) {
+	switch unit {
+	case pb.Memory_BIT:
+		return 1, nil
+	case pb.Memory_BYTE:
+		return 8, nil
+	case pb.Memory_KILOBYTE:
+		return 8 << 10, nil
+	case pb.Memory_MEGABYTE:
+		return 8 << 20, nil
+	case pb.Memory_GIGABYTE:
+		return 8 << 30, nil
+	case pb.Memory_TERABYTE:
+		return 8 << 40, nil
+	default:
+		return 0, fmt.Errorf("%w: %v", ErrUnknownUnit, unit)
+	}
+}
+
+func deepCopy(laptop *pb.Laptop) (*pb.Laptop, error) {
+	other := &pb.Laptop{}
+	err := copier.Copy(other, laptop)
+	if err != nil {
+		return nil, fmt.Errorf("cannot copy laptop data: %w", err)
+	}
+	return other, nil
+}
@@ -0,0 +1,131 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"grpc-3/pb"
+	"grpc-3/sample"
+	"grpc-3/service"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryLaptopStoreSearch(t *testing.T) {
+	t.Parallel()
+
+	store := service.NewInMemoryLaptopStore()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, store.Save(sample.NewLaptop()))
+	}
+
+	// the store is not locked while found runs, so it can write to the store
+	found := 0
+	err := store.Search(context.Background(), &pb.Filter{}, func(laptop *pb.Laptop) error {
+		found++
+		_, err := store.Mutate(laptop.Id, func(laptop *pb.Laptop) error {
+			return nil
+		})
+		return err
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, found)
+
+	// a laptop whose RAM unit cannot be compared is skipped, not an error
+	broken := sample.NewLaptop()
+	broken.Ram = &pb.Memory{Value: 64, Unit: pb.Memory_UNKNOWN}
+	require.NoError(t, store.Save(broken))
+	noRAM := sample.NewLaptop()
+	noRAM.Ram = nil
+	require.NoError(t, store.Save(noRAM))
+
+	found = 0
+	filter := &pb.Filter{MinRam: &pb.Memory{Value: 1, Unit: pb.Memory_BIT}}
+	err = store.Search(context.Background(), filter, func(laptop *pb.Laptop) error {
+		require.NotEqual(t, broken.Id, laptop.Id)
+		require.NotEqual(t, noRAM.Id, laptop.Id)
+		found++
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, found)
+}
+
+func TestLaptopStoreMutate(t *testing.T) {
+	t.Parallel()
+
+	fileStore, err := service.NewFileLaptopStore(filepath.Join(t.TempDir(), "laptops.log"))
+	require.NoError(t, err)
+	t.Cleanup(func() { fileStore.Close() })
+
+	stores := map[string]service.LaptopStore{
+		"memory": service.NewInMemoryLaptopStore(),
+		"file":   fileStore,
+	}
+
+	for name, store := range stores {
+		store := store
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			laptop := sample.NewLaptop()
+			laptop.PriceUsd = 1000
+			require.NoError(t, store.Save(laptop))
+
+			// a write made while fn runs waits for the mutation to be stored
+			started := make(chan struct{})
+			release := make(chan struct{})
+			mutated := make(chan error, 1)
+			go func() {
+				_, err := store.Mutate(laptop.Id, func(laptop *pb.Laptop) error {
+					close(started)
+					<-release
+					laptop.PriceUsd += 500
+					return nil
+				})
+				mutated <- err
+			}()
+			<-started
+
+			updated := make(chan error, 1)
+			go func() {
+				_, err := store.Mutate(laptop.Id, func(laptop *pb.Laptop) error {
+					laptop.PriceUsd = 2000
+					return nil
+				})
+				updated <- err
+			}()
+			select {
+			case <-updated:
+				t.Fatal("the second mutation did not wait for the first")
+			case <-time.After(50 * time.Millisecond):
+			}
+
+			close(release)
+			require.NoError(t, <-mutated)
+			require.NoError(t, <-updated)
+
+			stored, err := store.Find(laptop.Id)
+			require.NoError(t, err)
+			require.Equal(t, 2000.0, stored.PriceUsd)
+
+			// nothing is stored when fn fails
+			_, err = store.Mutate(laptop.Id, func(laptop *pb.Laptop) error {
+				laptop.PriceUsd = 1
+				return errors.New("rejected")
+			})
+			require.Error(t, err)
+			stored, err = store.Find(laptop.Id)
+			require.NoError(t, err)
+			require.Equal(t, 2000.0, stored.PriceUsd)
+
+			_, err = store.Mutate(sample.NewLaptop().Id, func(laptop *pb.Laptop) error {
+				return nil
+			})
+			require.ErrorIs(t, err, service.ErrNotFound)
+		})
+	}
+}
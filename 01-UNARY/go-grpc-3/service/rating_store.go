@@ -0,0 +1,49 @@
+package service
+
+import "sync"
+
+// Interface to RatingStore
+type RatingStore interface {
+	// Add adds a new laptop score to the store and returns its updated rating
+	Add(laptopID string, score float64) (*Rating, error)
+}
+
+// Rating contains the rating information of a laptop
+type Rating struct {
+	Count uint32
+	Sum   float64
+}
+
+// InMemoryRatingStore stores laptop ratings in memory
+type InMemoryRatingStore struct {
+	mutex  sync.RWMutex
+	rating map[string]*Rating
+}
+
+// NewInMemoryRatingStore creates new InMemoryRatingStore
+func NewInMemoryRatingStore() *InMemoryRatingStore {
+	return &InMemoryRatingStore{
+		rating: make(map[string]*Rating),
+	}
+}
+
+// Add adds a new laptop score to the store and returns its updated rating
+func (store *InMemoryRatingStore) Add(laptopID string, score float64) (*Rating, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	rating := store.rating[laptopID]
+	if rating == nil {
+		rating = &Rating{}
+		store.rating[laptopID] = rating
+	}
+
+	rating.Count++
+	rating.Sum += score
+
+	// return a copy so callers never race with later updates
+	return &Rating{
+		Count: rating.Count,
+		Sum:   rating.Sum,
+	}, nil
+}
@@ -0,0 +1,171 @@
+package validator
+
+import (
+	"fmt"
+	"grpc-3/pb"
+	"math"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// violations collects the field violations found so far
+type violations struct {
+	list []*errdetails.BadRequest_FieldViolation
+}
+
+func (v *violations) add(field string, format string, args ...any) {
+	v.list = append(v.list, &errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Description: fmt.Sprintf(format, args...),
+	})
+}
+
+// finite reports whether value is a finite number, adding a violation when it
+// is not. Range checks only hold for finite values: NaN passes every one.
+func (v *violations) finite(field string, value float64) bool {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		v.add(field, "must be a finite number, got %v", value)
+		return false
+	}
+	return true
+}
+
+// Laptop checks the semantic rules of a laptop and its components. It returns
+// nil when the laptop is valid, otherwise a BadRequest with one violation per
+// broken rule, each addressed by a field path such as "cpu.max_ghz".
+func Laptop(laptop *pb.Laptop) *errdetails.BadRequest {
+	v := &violations{}
+
+	if v.finite("price_usd", laptop.GetPriceUsd()) && laptop.GetPriceUsd() < 0 {
+		v.add("price_usd", "must not be negative, got %v", laptop.GetPriceUsd())
+	}
+
+	switch weight := laptop.GetWeight().(type) {
+	case *pb.Laptop_WeightKg:
+		if v.finite("weight_kg", weight.WeightKg) && weight.WeightKg <= 0 {
+			v.add("weight_kg", "must be positive, got %v", weight.WeightKg)
+		}
+	case *pb.Laptop_WeightLz:
+		if weight.WeightLz == 0 {
+			v.add("weight_lz", "must be positive")
+		}
+	}
+
+	cpu(v, "cpu", laptop.GetCpu())
+	memory(v, "ram", laptop.GetRam())
+
+	for i, g := range laptop.GetGpus() {
+		gpu(v, fmt.Sprintf("gpus[%d]", i), g)
+	}
+	for i, s := range laptop.GetStorages() {
+		storage(v, fmt.Sprintf("storages[%d]", i), s)
+	}
+
+	screen(v, "screen", laptop.GetScreen())
+	keyboard(v, "keyboard", laptop.GetKeyboard())
+
+	if len(v.list) == 0 {
+		return nil
+	}
+	return &errdetails.BadRequest{FieldViolations: v.list}
+}
+
+func cpu(v *violations, path string, cpu *pb.CPU) {
+	if cpu == nil {
+		v.add(path, "is required")
+		return
+	}
+
+	if cpu.GetNumberCores() == 0 {
+		v.add(path+".number_cores", "must be positive")
+	}
+	if cpu.GetNumberThreads() < cpu.GetNumberCores() {
+		v.add(path+".number_threads", "must not be fewer than number_cores (%d), got %d", cpu.GetNumberCores(), cpu.GetNumberThreads())
+	}
+	frequency(v, path, cpu.GetMinGhz(), cpu.GetMaxGhz())
+}
+
+func gpu(v *violations, path string, gpu *pb.GPU) {
+	if gpu == nil {
+		v.add(path, "is required")
+		return
+	}
+
+	frequency(v, path, gpu.GetMinGhz(), gpu.GetMaxGhz())
+	memory(v, path+".memory", gpu.GetMemory())
+}
+
+func frequency(v *violations, path string, minGhz float64, maxGhz float64) {
+	minFinite := v.finite(path+".min_ghz", minGhz)
+	maxFinite := v.finite(path+".max_ghz", maxGhz)
+	if minFinite && minGhz <= 0 {
+		v.add(path+".min_ghz", "must be positive, got %v", minGhz)
+	}
+	if minFinite && maxFinite && maxGhz < minGhz {
+		v.add(path+".max_ghz", "must not be lower than min_ghz (%v), got %v", minGhz, maxGhz)
+	}
+}
+
+func memory(v *violations, path string, memory *pb.Memory) {
+	if memory == nil {
+		v.add(path, "is required")
+		return
+	}
+
+	if memory.GetValue() == 0 {
+		v.add(path+".value", "must be positive")
+	}
+	if memory.GetUnit() == pb.Memory_UNKNOWN {
+		v.add(path+".unit", "must be specified")
+	}
+}
+
+func storage(v *violations, path string, storage *pb.Storage) {
+	if storage == nil {
+		v.add(path, "is required")
+		return
+	}
+
+	if storage.GetDriver() == pb.Storage_UNKNOWN {
+		v.add(path+".driver", "must be specified")
+	}
+	memory(v, path+".memory", storage.GetMemory())
+}
+
+func screen(v *violations, path string, screen *pb.Screen) {
+	if screen == nil {
+		v.add(path, "is required")
+		return
+	}
+
+	if v.finite(path+".size_inch", float64(screen.GetSizeInch())) && screen.GetSizeInch() <= 0 {
+		v.add(path+".size_inch", "must be positive, got %v", screen.GetSizeInch())
+	}
+
+	resolution := screen.GetResolution()
+	if resolution == nil {
+		v.add(path+".resolution", "is required")
+	} else {
+		if resolution.GetWidth() == 0 {
+			v.add(path+".resolution.width", "must be positive")
+		}
+		if resolution.GetHeight() == 0 {
+			v.add(path+".resolution.height", "must be positive")
+		}
+	}
+
+	if screen.GetPanel() == pb.Screen_UNKNOWN {
+		v.add(path+".panel", "must be specified")
+	}
+}
+
+func keyboard(v *violations, path string, keyboard *pb.Keyboard) {
+	if keyboard == nil {
+		v.add(path, "is required")
+		return
+	}
+
+	if keyboard.GetLayout() == pb.Keyboard_UNKNOWN {
+		v.add(path+".layout", "must be specified")
+	}
+}
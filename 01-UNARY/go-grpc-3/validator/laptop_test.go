@@ -0,0 +1,149 @@
+package validator_test
+
+import (
+	"grpc-3/pb"
+	"grpc-3/sample"
+	"grpc-3/validator"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLaptop(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		modify func(laptop *pb.Laptop)
+		fields []string
+	}{
+		{
+			name:   "valid",
+			modify: func(laptop *pb.Laptop) {},
+		},
+		{
+			name: "cpu_frequency_inverted",
+			modify: func(laptop *pb.Laptop) {
+				laptop.Cpu.MinGhz = 3.5
+				laptop.Cpu.MaxGhz = 2.5
+			},
+			fields: []string{"cpu.max_ghz"},
+		},
+		{
+			name: "cpu_zero_cores",
+			modify: func(laptop *pb.Laptop) {
+				laptop.Cpu.NumberCores = 0
+			},
+			fields: []string{"cpu.number_cores"},
+		},
+		{
+			name: "cpu_fewer_threads_than_cores",
+			modify: func(laptop *pb.Laptop) {
+				laptop.Cpu.NumberCores = 8
+				laptop.Cpu.NumberThreads = 4
+			},
+			fields: []string{"cpu.number_threads"},
+		},
+		{
+			name: "negative_price",
+			modify: func(laptop *pb.Laptop) {
+				laptop.PriceUsd = -1
+			},
+			fields: []string{"price_usd"},
+		},
+		{
+			name: "nan_price",
+			modify: func(laptop *pb.Laptop) {
+				laptop.PriceUsd = math.NaN()
+			},
+			fields: []string{"price_usd"},
+		},
+		{
+			name: "infinite_weight",
+			modify: func(laptop *pb.Laptop) {
+				laptop.Weight = &pb.Laptop_WeightKg{WeightKg: math.Inf(1)}
+			},
+			fields: []string{"weight_kg"},
+		},
+		{
+			name: "cpu_nan_min_frequency",
+			modify: func(laptop *pb.Laptop) {
+				laptop.Cpu.MinGhz = math.NaN()
+			},
+			fields: []string{"cpu.min_ghz"},
+		},
+		{
+			name: "cpu_nan_max_frequency",
+			modify: func(laptop *pb.Laptop) {
+				laptop.Cpu.MaxGhz = math.NaN()
+			},
+			fields: []string{"cpu.max_ghz"},
+		},
+		{
+			name: "gpu_infinite_frequency",
+			modify: func(laptop *pb.Laptop) {
+				laptop.Gpus[0].MinGhz = math.Inf(-1)
+				laptop.Gpus[0].MaxGhz = math.Inf(1)
+			},
+			fields: []string{"gpus[0].min_ghz", "gpus[0].max_ghz"},
+		},
+		{
+			name: "nan_screen_size",
+			modify: func(laptop *pb.Laptop) {
+				laptop.Screen.SizeInch = float32(math.NaN())
+			},
+			fields: []string{"screen.size_inch"},
+		},
+		{
+			name: "missing_components",
+			modify: func(laptop *pb.Laptop) {
+				laptop.Cpu = nil
+				laptop.Ram = nil
+				laptop.Screen = nil
+				laptop.Keyboard = nil
+			},
+			fields: []string{"cpu", "ram", "screen", "keyboard"},
+		},
+		{
+			name: "nested_violations",
+			modify: func(laptop *pb.Laptop) {
+				laptop.Gpus[0].Memory.Value = 0
+				laptop.Storages[1].Driver = pb.Storage_UNKNOWN
+				laptop.Screen.Resolution.Height = 0
+				laptop.Keyboard.Layout = pb.Keyboard_UNKNOWN
+			},
+			fields: []string{
+				"gpus[0].memory.value",
+				"storages[1].driver",
+				"screen.resolution.height",
+				"keyboard.layout",
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			laptop := sample.NewLaptop()
+			tc.modify(laptop)
+
+			badRequest := validator.Laptop(laptop)
+			if len(tc.fields) == 0 {
+				require.Nil(t, badRequest)
+				return
+			}
+
+			require.NotNil(t, badRequest)
+			fields := []string{}
+			for _, violation := range badRequest.GetFieldViolations() {
+				require.NotEmpty(t, violation.GetDescription())
+				fields = append(fields, violation.GetField())
+			}
+			require.ElementsMatch(t, tc.fields, fields)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package alert
+
+import (
+	"errors"
+	"fmt"
+	"grpc-1/weather"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRule is wrapped by the errors of rules that cannot be evaluated
+var ErrInvalidRule = errors.New("invalid rule")
+
+// Metric is a measure of an observation that rules compare
+type Metric int
+
+const (
+	Temperature Metric = iota + 1
+	Humidity
+	WindSpeed
+)
+
+// Value returns the metric in observation
+func (metric Metric) Value(observation weather.Observation) float64 {
+	switch metric {
+	case Temperature:
+		return observation.TemperatureCelsius
+	case Humidity:
+		return observation.HumidityPercent
+	case WindSpeed:
+		return observation.WindSpeedKph
+	}
+	return 0
+}
+
+// Rule holds when Metric of City is above, or below, Threshold
+type Rule struct {
+	ID        string
+	City      string
+	Metric    Metric
+	Above     bool
+	Threshold float64
+	// Debounce is how long the rule must hold, or stop holding, before it
+	// fires or resolves
+	Debounce time.Duration
+}
+
+func (rule Rule) validate() error {
+	switch {
+	case strings.TrimSpace(rule.ID) == "":
+		return fmt.Errorf("%w: id is required", ErrInvalidRule)
+	case strings.TrimSpace(rule.City) == "":
+		return fmt.Errorf("%w: city is required", ErrInvalidRule)
+	case rule.Metric < Temperature || rule.Metric > WindSpeed:
+		return fmt.Errorf("%w: unknown metric %d", ErrInvalidRule, rule.Metric)
+	case rule.Debounce < 0:
+		return fmt.Errorf("%w: debounce must not be negative", ErrInvalidRule)
+	}
+	return nil
+}
+
+func (rule Rule) holds(value float64) bool {
+	if rule.Above {
+		return value > rule.Threshold
+	}
+	return value < rule.Threshold
+}
+
+// Event is a rule starting to fire or resolving
+type Event struct {
+	Rule   Rule
+	Firing bool
+	// Value is the metric of the observation that changed the state
+	Value      float64
+	ObservedAt time.Time
+}
+
+// ruleState tracks a rule between observations
+type ruleState struct {
+	rule   Rule
+	firing bool
+	// changing is set while the rule differs from firing, since the
+	// observation at changingSince
+	changing      bool
+	changingSince time.Time
+}
+
+// Evaluator evaluates rules against the observations of a subscription.
+// It is not safe for concurrent use.
+type Evaluator struct {
+	rules map[string]*ruleState
+}
+
+// NewEvaluator returns an evaluator without rules
+func NewEvaluator() *Evaluator {
+	return &Evaluator{rules: map[string]*ruleState{}}
+}
+
+// Len returns the number of rules
+func (evaluator *Evaluator) Len() int {
+	return len(evaluator.rules)
+}
+
+// Add adds rule, replacing the rule with the same id. A replaced rule starts
+// over as not firing.
+func (evaluator *Evaluator) Add(rule Rule) error {
+	err := rule.validate()
+	if err != nil {
+		return err
+	}
+	evaluator.rules[rule.ID] = &ruleState{rule: rule}
+	return nil
+}
+
+// Remove removes the rule with id and reports whether there was one
+func (evaluator *Evaluator) Remove(id string) bool {
+	_, ok := evaluator.rules[id]
+	delete(evaluator.rules, id)
+	return ok
+}
+
+// Evaluate applies observation to the rules of its city and returns the
+// rules that started firing or resolved, ordered by id. Observations of a
+// city must come in the order they were observed.
+func (evaluator *Evaluator) Evaluate(observation weather.Observation) []Event {
+	events := []Event{}
+	for _, state := range evaluator.rules {
+		rule := state.rule
+		if !strings.EqualFold(strings.TrimSpace(rule.City), strings.TrimSpace(observation.City)) {
+			continue
+		}
+
+		value := rule.Metric.Value(observation)
+		if rule.holds(value) == state.firing {
+			state.changing = false
+			continue
+		}
+		if !state.changing {
+			state.changing = true
+			state.changingSince = observation.ObservedAt
+		}
+		if observation.ObservedAt.Sub(state.changingSince) < rule.Debounce {
+			continue
+		}
+
+		state.firing = !state.firing
+		state.changing = false
+		events = append(events, Event{
+			Rule:       rule,
+			Firing:     state.firing,
+			Value:      value,
+			ObservedAt: observation.ObservedAt,
+		})
+	}
+
+	sort.Slice(events, func(i, j int) bool { return events[i].Rule.ID < events[j].Rule.ID })
+	return events
+}
@@ -0,0 +1,142 @@
+package alert_test
+
+import (
+	"context"
+	"grpc-1/alert"
+	"grpc-1/weather"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// simulate returns n observations of city from the simulated provider, one
+// minute apart
+func simulate(t *testing.T, city string, n int) []weather.Observation {
+	at := time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC)
+	provider := weather.NewSimulatedProvider(func() time.Time { return at }, time.Minute)
+
+	observations := []weather.Observation{}
+	for i := 0; i < n; i++ {
+		observation, err := provider.Current(context.Background(), city)
+		require.NoError(t, err)
+		observations = append(observations, observation)
+		at = at.Add(time.Minute)
+	}
+	return observations
+}
+
+func TestEvaluatorWithoutDebounce(t *testing.T) {
+	t.Parallel()
+
+	observations := simulate(t, "London", 24*60)
+	// the humidity of the first observation flaps a lot around the day
+	threshold := observations[0].HumidityPercent
+	rule := alert.Rule{ID: "humid", City: "london", Metric: alert.Humidity, Above: true, Threshold: threshold}
+
+	evaluator := alert.NewEvaluator()
+	require.NoError(t, evaluator.Add(rule))
+	require.NoError(t, evaluator.Add(alert.Rule{ID: "paris", City: "Paris", Metric: alert.Humidity, Above: true}))
+
+	// without debounce every crossing is an event
+	firing := false
+	expected := []alert.Event{}
+	for _, observation := range observations {
+		if (observation.HumidityPercent > threshold) != firing {
+			firing = !firing
+			expected = append(expected, alert.Event{
+				Rule:       rule,
+				Firing:     firing,
+				Value:      observation.HumidityPercent,
+				ObservedAt: observation.ObservedAt,
+			})
+		}
+	}
+	require.Greater(t, len(expected), 10)
+
+	events := []alert.Event{}
+	for _, observation := range observations {
+		events = append(events, evaluator.Evaluate(observation)...)
+	}
+	require.Equal(t, expected, events)
+}
+
+func TestEvaluatorDebounce(t *testing.T) {
+	t.Parallel()
+
+	observations := simulate(t, "London", 24*60)
+	threshold := observations[0].HumidityPercent
+	debounce := 3 * time.Minute
+
+	for _, above := range []bool{true, false} {
+		rule := alert.Rule{ID: "humid", City: "London", Metric: alert.Humidity, Above: above, Threshold: threshold, Debounce: debounce}
+		holds := func(observation weather.Observation) bool {
+			if above {
+				return observation.HumidityPercent > threshold
+			}
+			return observation.HumidityPercent < threshold
+		}
+
+		evaluator := alert.NewEvaluator()
+		require.NoError(t, evaluator.Add(rule))
+
+		undebounced := alert.NewEvaluator()
+		flapping := rule
+		flapping.Debounce = 0
+		require.NoError(t, undebounced.Add(flapping))
+
+		events, flaps := 0, 0
+		firing := false
+		for i, observation := range observations {
+			flaps += len(undebounced.Evaluate(observation))
+			for _, event := range evaluator.Evaluate(observation) {
+				events++
+				require.NotEqual(t, firing, event.Firing, "events alternate between firing and resolved")
+				firing = event.Firing
+
+				// the new state held for every observation of the debounce
+				steps := int(debounce / time.Minute)
+				require.GreaterOrEqual(t, i, steps)
+				for _, previous := range observations[i-steps : i+1] {
+					require.Equal(t, event.Firing, holds(previous), "at %s", previous.ObservedAt)
+				}
+			}
+		}
+		require.Positive(t, events)
+		require.Less(t, 4*events, flaps, "debounce drops most of the flapping")
+	}
+}
+
+func TestEvaluatorRules(t *testing.T) {
+	t.Parallel()
+
+	evaluator := alert.NewEvaluator()
+	invalid := []alert.Rule{
+		{City: "London", Metric: alert.Temperature},
+		{ID: "no-city", Metric: alert.Temperature},
+		{ID: "no-metric", City: "London"},
+		{ID: "negative", City: "London", Metric: alert.WindSpeed, Debounce: -time.Second},
+	}
+	for _, rule := range invalid {
+		require.ErrorIs(t, evaluator.Add(rule), alert.ErrInvalidRule)
+	}
+
+	observation := simulate(t, "London", 1)[0]
+	require.NoError(t, evaluator.Add(alert.Rule{ID: "cold", City: "London", Metric: alert.Temperature, Threshold: 100}))
+	require.NoError(t, evaluator.Add(alert.Rule{ID: "any-wind", City: "London", Metric: alert.WindSpeed, Above: true, Threshold: -1}))
+	require.Equal(t, 2, evaluator.Len())
+
+	events := evaluator.Evaluate(observation)
+	require.Len(t, events, 2)
+	require.Equal(t, "any-wind", events[0].Rule.ID)
+	require.Equal(t, "cold", events[1].Rule.ID)
+	require.Empty(t, evaluator.Evaluate(observation))
+
+	// a replaced rule starts over
+	require.NoError(t, evaluator.Add(alert.Rule{ID: "cold", City: "London", Metric: alert.Temperature, Threshold: 100}))
+	require.Len(t, evaluator.Evaluate(observation), 1)
+
+	require.True(t, evaluator.Remove("cold"))
+	require.False(t, evaluator.Remove("cold"))
+	require.Equal(t, 1, evaluator.Len())
+}
@@ -4,9 +4,15 @@ import (
 	"context"
 	"grpc-1/pb"
 	"log"
+	"os"
+	"os/signal"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 func main() {
@@ -17,23 +23,124 @@ func main() {
 	defer conn.Close()
 	c := pb.NewWeatherServiceClient(conn)
 
+	// stop receiving updates on Ctrl+C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	getWeatherUpdates(ctx, c)
+	getWeatherHistory(ctx, c)
+	subscribeWeather(ctx, c)
+}
+
+// getWeatherUpdates prints three updates of London
+func getWeatherUpdates(ctx context.Context, c pb.WeatherServiceClient) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	req := &pb.WeatherRequest{
-		City: "London",
+		City:     "London",
+		Interval: durationpb.New(time.Second),
 	}
-	stream, err := c.GetWeatherUpdates(context.Background(), req)
+	stream, err := c.GetWeatherUpdates(ctx, req)
 	if err != nil {
 		log.Fatalf("failed to call GetWeatherUpdates: %v", err)
 	}
 
-	for {
+	for i := 0; i < 3; i++ {
 		res, err := stream.Recv()
+		if status.Code(err) == codes.Canceled {
+			return
+		}
 		if err != nil {
 			log.Fatalf("error recieving: %v", err)
-			break
 		}
+		logUpdate(res)
+	}
+}
+
+// getWeatherHistory prints the hourly summary of London over the last day
+func getWeatherHistory(ctx context.Context, c pb.WeatherServiceClient) {
+	res, err := c.GetWeatherHistory(ctx, &pb.WeatherHistoryRequest{
+		City:       "London",
+		Resolution: pb.HistoryResolution_HISTORY_RESOLUTION_HOURLY,
+	})
+	if err != nil {
+		log.Fatalf("failed to call GetWeatherHistory: %v", err)
+	}
+
+	for _, bucket := range res.Buckets {
 		log.Printf(
-			"Weather Update: %s, %s, %s, %s", 
-			res.City, res.Temperature, res.Weather, res.Update,
+			"Weather History: %s from %s, %d observations, %.1f°C to %.1f°C, avg %.1f°C",
+			res.City, bucket.Start.AsTime().Format(time.RFC3339), bucket.Count,
+			bucket.TemperatureCelsius.Min, bucket.TemperatureCelsius.Max, bucket.TemperatureCelsius.Avg,
 		)
 	}
-}
\ No newline at end of file
+}
+
+// subscribeWeather watches London and Paris with a humidity alert on London,
+// then swaps Paris for Tokyo and slows down, until Ctrl+C
+func subscribeWeather(ctx context.Context, c pb.WeatherServiceClient) {
+	stream, err := c.SubscribeWeather(ctx)
+	if err != nil {
+		log.Fatalf("failed to call SubscribeWeather: %v", err)
+	}
+
+	err = stream.Send(&pb.SubscribeWeatherRequest{
+		AddCities: []string{"London", "Paris"},
+		Interval:  durationpb.New(time.Second),
+		AddRules: []*pb.AlertRule{{
+			Id:         "london-humid",
+			City:       "London",
+			Metric:     pb.WeatherMetric_WEATHER_METRIC_HUMIDITY,
+			Comparison: pb.AlertComparison_ALERT_COMPARISON_ABOVE,
+			Threshold:  80,
+			Debounce:   durationpb.New(2 * time.Second),
+		}},
+	})
+	if err != nil {
+		log.Fatalf("failed to subscribe: %v", err)
+	}
+
+	for i := 1; ; i++ {
+		res, err := stream.Recv()
+		if status.Code(err) == codes.Canceled {
+			log.Println("stopped receiving updates")
+			return
+		}
+		if err != nil {
+			log.Fatalf("error recieving: %v", err)
+		}
+		if alert := res.GetAlert(); alert != nil {
+			log.Printf(
+				"Weather Alert: %s %s, %s %.1f against %s %.1f",
+				alert.RuleId, alert.State, alert.Metric, alert.Value, alert.Comparison, alert.Threshold,
+			)
+			continue
+		}
+		if cityError := res.GetError(); cityError != nil {
+			log.Printf("Weather Error: %s, %s", cityError.City, cityError.Message)
+			continue
+		}
+		logUpdate(res.GetUpdate())
+
+		if i == 6 {
+			log.Println("replacing Paris with Tokyo, every 2s")
+			err = stream.Send(&pb.SubscribeWeatherRequest{
+				RemoveCities: []string{"Paris"},
+				AddCities:    []string{"Tokyo"},
+				Interval:     durationpb.New(2 * time.Second),
+			})
+			if err != nil {
+				log.Fatalf("failed to change subscription: %v", err)
+			}
+		}
+	}
+}
+
+func logUpdate(res *pb.WeatherResponse) {
+	log.Printf(
+		"Weather Update: %s, %s, %.1f°C, %.0f%% humidity, wind %.1f km/h from %.0f°, at %s",
+		res.City, res.Weather, res.TemperatureCelsius, res.HumidityPercent,
+		res.WindSpeedKph, res.WindDirectionDegrees, res.ObservedAt.AsTime().Format(time.RFC3339),
+	)
+}
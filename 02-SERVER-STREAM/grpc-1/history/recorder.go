@@ -0,0 +1,56 @@
+package history
+
+import (
+	"context"
+	"grpc-1/weather"
+	"log"
+	"time"
+)
+
+// Recorder is a provider that stores every observation of the provider it
+// wraps. An observation that cannot be stored is logged and still returned,
+// so the live weather does not depend on the history.
+type Recorder struct {
+	provider weather.WeatherProvider
+	store    *SQLiteStore
+}
+
+// NewRecorder returns a provider recording the observations of provider in store
+func NewRecorder(provider weather.WeatherProvider, store *SQLiteStore) *Recorder {
+	return &Recorder{provider: provider, store: store}
+}
+
+func (recorder *Recorder) Current(ctx context.Context, city string) (weather.Observation, error) {
+	observation, err := recorder.provider.Current(ctx, city)
+	if err != nil {
+		return observation, err
+	}
+
+	err = recorder.store.Add(ctx, observation)
+	if err != nil {
+		log.Printf("cannot record weather of %s: %v", city, err)
+	}
+	return observation, nil
+}
+
+// Prune deletes the observations older than retention every interval,
+// until ctx is done
+func Prune(ctx context.Context, store *SQLiteStore, retention time.Duration, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := store.DeleteBefore(ctx, time.Now().Add(-retention))
+		if err != nil {
+			log.Printf("cannot prune weather history: %v", err)
+		} else if n > 0 {
+			log.Printf("pruned %d observations older than %s", n, retention)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package history
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"grpc-1/weather"
+	"math"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// migrations are applied in order, PRAGMA user_version records how many ran
+var migrations = []string{
+	`CREATE TABLE observations (
+		city_key       TEXT NOT NULL,
+		observed_at    INTEGER NOT NULL,
+		city           TEXT NOT NULL,
+		condition      TEXT NOT NULL,
+		temperature    REAL NOT NULL,
+		humidity       REAL NOT NULL,
+		wind_speed     REAL NOT NULL,
+		wind_direction REAL NOT NULL,
+		PRIMARY KEY (city_key, observed_at)
+	) WITHOUT ROWID`,
+	`CREATE INDEX observations_observed_at ON observations (observed_at)`,
+}
+
+// minTime and maxTime are the first and last times observed_at can hold
+var (
+	minTime = time.Unix(0, math.MinInt64)
+	maxTime = time.Unix(0, math.MaxInt64)
+)
+
+// Summary is the minimum, maximum and average of a metric over a bucket
+type Summary struct {
+	Min float64
+	Max float64
+	Avg float64
+}
+
+// Bucket summarizes the observations of a city from Start, for the bucket
+// duration of the query
+type Bucket struct {
+	Start              time.Time
+	Count              int
+	TemperatureCelsius Summary
+	HumidityPercent    Summary
+	WindSpeedKph       Summary
+}
+
+// SQLiteStore stores observations in a SQLite database
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore opens the database at path, creating and migrating it if needed
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite3", path+"?_busy_timeout=5000&_journal_mode=WAL")
+	if err != nil {
+		return nil, fmt.Errorf("cannot open database: %w", err)
+	}
+
+	store := &SQLiteStore{db: db}
+	err = store.migrate(context.Background())
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (store *SQLiteStore) migrate(ctx context.Context) error {
+	var version int
+	err := store.db.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version)
+	if err != nil {
+		return fmt.Errorf("cannot read schema version: %w", err)
+	}
+
+	for ; version < len(migrations); version++ {
+		tx, err := store.db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("cannot begin migration: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, migrations[version])
+		if err == nil {
+			_, err = tx.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", version+1))
+		}
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("cannot apply migration %d: %w", version+1, err)
+		}
+
+		err = tx.Commit()
+		if err != nil {
+			return fmt.Errorf("cannot commit migration %d: %w", version+1, err)
+		}
+	}
+
+	return nil
+}
+
+// Close closes the database
+func (store *SQLiteStore) Close() error {
+	return store.db.Close()
+}
+
+// unixNano returns t as stored in observed_at. Times an int64 of nanoseconds
+// cannot hold, before 1678 or after 2262, are clamped to its range, which
+// holds every stored observation anyway.
+func unixNano(t time.Time) int64 {
+	switch {
+	case t.Before(minTime):
+		return math.MinInt64
+	case t.After(maxTime):
+		return math.MaxInt64
+	default:
+		return t.UnixNano()
+	}
+}
+
+// Add stores observation. An observation of the same city at the same time
+// is only stored once.
+func (store *SQLiteStore) Add(ctx context.Context, observation weather.Observation) error {
+	_, err := store.db.ExecContext(ctx,
+		`INSERT OR IGNORE INTO observations
+		(city_key, observed_at, city, condition, temperature, humidity, wind_speed, wind_direction)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		weather.CityKey(observation.City),
+		observation.ObservedAt.UnixNano(),
+		observation.City,
+		observation.Condition,
+		observation.TemperatureCelsius,
+		observation.HumidityPercent,
+		observation.WindSpeedKph,
+		observation.WindDirectionDegrees,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot insert observation: %w", err)
+	}
+	return nil
+}
+
+// Observations returns up to limit observations of city from from, inclusive,
+// to to, exclusive, oldest first. A limit of 0 returns them all.
+func (store *SQLiteStore) Observations(ctx context.Context, city string, from time.Time, to time.Time, limit int) ([]weather.Observation, error) {
+	statement := `SELECT city, observed_at, condition, temperature, humidity, wind_speed, wind_direction
+		FROM observations
+		WHERE city_key = ? AND observed_at >= ? AND observed_at < ?
+		ORDER BY observed_at`
+	args := []any{weather.CityKey(city), unixNano(from), unixNano(to)}
+	if limit > 0 {
+		statement += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := store.db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot query observations: %w", err)
+	}
+	defer rows.Close()
+
+	observations := []weather.Observation{}
+	for rows.Next() {
+		var observation weather.Observation
+		var observedAt int64
+		err := rows.Scan(
+			&observation.City,
+			&observedAt,
+			&observation.Condition,
+			&observation.TemperatureCelsius,
+			&observation.HumidityPercent,
+			&observation.WindSpeedKph,
+			&observation.WindDirectionDegrees,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read observation: %w", err)
+		}
+		observation.ObservedAt = time.Unix(0, observedAt).UTC()
+		observations = append(observations, observation)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("cannot query observations: %w", err)
+	}
+	return observations, nil
+}
+
+// Buckets summarizes the observations of city from from, inclusive, to to,
+// exclusive, in buckets of size. Buckets start at multiples of size since
+// the Unix epoch, so daily buckets are UTC days. Buckets without
+// observations are left out.
+func (store *SQLiteStore) Buckets(ctx context.Context, city string, from time.Time, to time.Time, size time.Duration) ([]Bucket, error) {
+	rows, err := store.db.QueryContext(ctx,
+		`SELECT observed_at / ? AS bucket, COUNT(*),
+			MIN(temperature), MAX(temperature), AVG(temperature),
+			MIN(humidity), MAX(humidity), AVG(humidity),
+			MIN(wind_speed), MAX(wind_speed), AVG(wind_speed)
+		FROM observations
+		WHERE city_key = ? AND observed_at >= ? AND observed_at < ?
+		GROUP BY bucket
+		ORDER BY bucket`,
+		int64(size), weather.CityKey(city), unixNano(from), unixNano(to),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot query buckets: %w", err)
+	}
+	defer rows.Close()
+
+	buckets := []Bucket{}
+	for rows.Next() {
+		var bucket Bucket
+		var index int64
+		err := rows.Scan(
+			&index, &bucket.Count,
+			&bucket.TemperatureCelsius.Min, &bucket.TemperatureCelsius.Max, &bucket.TemperatureCelsius.Avg,
+			&bucket.HumidityPercent.Min, &bucket.HumidityPercent.Max, &bucket.HumidityPercent.Avg,
+			&bucket.WindSpeedKph.Min, &bucket.WindSpeedKph.Max, &bucket.WindSpeedKph.Avg,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read bucket: %w", err)
+		}
+		bucket.Start = time.Unix(0, index*int64(size)).UTC()
+		buckets = append(buckets, bucket)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("cannot query buckets: %w", err)
+	}
+	return buckets, nil
+}
+
+// DeleteBefore deletes the observations made before before and returns how many there were
+func (store *SQLiteStore) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := store.db.ExecContext(ctx, `DELETE FROM observations WHERE observed_at < ?`, unixNano(before))
+	if err != nil {
+		return 0, fmt.Errorf("cannot delete observations: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("cannot count deleted observations: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,141 @@
+package history_test
+
+import (
+	"context"
+	"grpc-1/history"
+	"grpc-1/weather"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newStore(t *testing.T) *history.SQLiteStore {
+	store, err := history.NewSQLiteStore(filepath.Join(t.TempDir(), "weather.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+var base = time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC)
+
+// observation returns an observation of London at base plus minutes
+func observation(minutes int, temperature float64) weather.Observation {
+	return weather.Observation{
+		City:                 "London",
+		Condition:            "sunny",
+		TemperatureCelsius:   temperature,
+		HumidityPercent:      50 + temperature,
+		WindSpeedKph:         2 * temperature,
+		WindDirectionDegrees: 90,
+		ObservedAt:           base.Add(time.Duration(minutes) * time.Minute),
+	}
+}
+
+func TestStoreObservations(t *testing.T) {
+	t.Parallel()
+
+	store := newStore(t)
+	ctx := context.Background()
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, store.Add(ctx, observation(i*30, float64(i))))
+	}
+	// the same city at the same time is stored once
+	require.NoError(t, store.Add(ctx, observation(0, 100)))
+	require.NoError(t, store.Add(ctx, weather.Observation{City: "Paris", ObservedAt: base}))
+
+	observations, err := store.Observations(ctx, " LONDON", base.Add(time.Hour), base.Add(3*time.Hour), 0)
+	require.NoError(t, err)
+	require.Equal(t, []weather.Observation{
+		observation(60, 2), observation(90, 3), observation(120, 4), observation(150, 5),
+	}, observations)
+
+	observations, err = store.Observations(ctx, "London", base, base.Add(24*time.Hour), 3)
+	require.NoError(t, err)
+	require.Len(t, observations, 3)
+	require.Equal(t, observation(0, 0), observations[0])
+
+	// a range beyond what UnixNano can represent covers every observation
+	observations, err = store.Observations(ctx, "London",
+		time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC), 0)
+	require.NoError(t, err)
+	require.Len(t, observations, 10)
+
+	n, err := store.DeleteBefore(ctx, base.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, int64(5), n)
+
+	observations, err = store.Observations(ctx, "London", base, base.Add(24*time.Hour), 0)
+	require.NoError(t, err)
+	require.Len(t, observations, 6)
+	require.Equal(t, observation(120, 4), observations[0])
+}
+
+func TestStoreBuckets(t *testing.T) {
+	t.Parallel()
+
+	store := newStore(t)
+	ctx := context.Background()
+
+	// 1, 2, 3 in the first hour, nothing in the second, 10 in the third
+	// and 20 on the next day
+	for _, o := range []weather.Observation{
+		observation(0, 1), observation(20, 2), observation(40, 3),
+		observation(150, 10), observation(24*60+5, 20),
+	} {
+		require.NoError(t, store.Add(ctx, o))
+	}
+
+	buckets, err := store.Buckets(ctx, "London", base, base.Add(48*time.Hour), time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, []history.Bucket{
+		{
+			Start:              base,
+			Count:              3,
+			TemperatureCelsius: history.Summary{Min: 1, Max: 3, Avg: 2},
+			HumidityPercent:    history.Summary{Min: 51, Max: 53, Avg: 52},
+			WindSpeedKph:       history.Summary{Min: 2, Max: 6, Avg: 4},
+		},
+		{
+			Start:              base.Add(2 * time.Hour),
+			Count:              1,
+			TemperatureCelsius: history.Summary{Min: 10, Max: 10, Avg: 10},
+			HumidityPercent:    history.Summary{Min: 60, Max: 60, Avg: 60},
+			WindSpeedKph:       history.Summary{Min: 20, Max: 20, Avg: 20},
+		},
+		{
+			Start:              base.Add(24 * time.Hour),
+			Count:              1,
+			TemperatureCelsius: history.Summary{Min: 20, Max: 20, Avg: 20},
+			HumidityPercent:    history.Summary{Min: 70, Max: 70, Avg: 70},
+			WindSpeedKph:       history.Summary{Min: 40, Max: 40, Avg: 40},
+		},
+	}, buckets)
+
+	buckets, err = store.Buckets(ctx, "London", base, base.Add(48*time.Hour), 24*time.Hour)
+	require.NoError(t, err)
+	require.Len(t, buckets, 2)
+	require.Equal(t, 4, buckets[0].Count)
+	require.Equal(t, history.Summary{Min: 1, Max: 10, Avg: 4}, buckets[0].TemperatureCelsius)
+	require.Equal(t, base.Add(24*time.Hour), buckets[1].Start)
+}
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+
+	store := newStore(t)
+	ctx := context.Background()
+	now := base.Add(90 * time.Second)
+	recorder := history.NewRecorder(weather.NewSimulatedProvider(func() time.Time { return now }, time.Minute), store)
+
+	recorded, err := recorder.Current(ctx, "London")
+	require.NoError(t, err)
+	_, err = recorder.Current(ctx, "")
+	require.ErrorIs(t, err, weather.ErrUnknownCity)
+
+	observations, err := store.Observations(ctx, "London", base, base.Add(time.Hour), 0)
+	require.NoError(t, err)
+	require.Equal(t, []weather.Observation{recorded}, observations)
+}
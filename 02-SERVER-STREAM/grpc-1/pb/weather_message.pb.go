@@ -9,6 +9,8 @@ package pb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -20,31 +22,829 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type WeatherMetric int32
+
+const (
+	WeatherMetric_WEATHER_METRIC_UNSPECIFIED WeatherMetric = 0
+	WeatherMetric_WEATHER_METRIC_TEMPERATURE WeatherMetric = 1
+	WeatherMetric_WEATHER_METRIC_HUMIDITY    WeatherMetric = 2
+	WeatherMetric_WEATHER_METRIC_WIND_SPEED  WeatherMetric = 3
+)
+
+// Enum value maps for WeatherMetric.
+var (
+	WeatherMetric_name = map[int32]string{
+		0: "WEATHER_METRIC_UNSPECIFIED",
+		1: "WEATHER_METRIC_TEMPERATURE",
+		2: "WEATHER_METRIC_HUMIDITY",
+		3: "WEATHER_METRIC_WIND_SPEED",
+	}
+	WeatherMetric_value = map[string]int32{
+		"WEATHER_METRIC_UNSPECIFIED": 0,
+		"WEATHER_METRIC_TEMPERATURE": 1,
+		"WEATHER_METRIC_HUMIDITY":    2,
+		"WEATHER_METRIC_WIND_SPEED":  3,
+	}
+)
+
+func (x WeatherMetric) Enum() *WeatherMetric {
+	p := new(WeatherMetric)
+	*p = x
+	return p
+}
+
+func (x WeatherMetric) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WeatherMetric) Descriptor() protoreflect.EnumDescriptor {
+	return file_weather_message_proto_enumTypes[0].Descriptor()
+}
+
+func (WeatherMetric) Type() protoreflect.EnumType {
+	return &file_weather_message_proto_enumTypes[0]
+}
+
+func (x WeatherMetric) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WeatherMetric.Descriptor instead.
+func (WeatherMetric) EnumDescriptor() ([]byte, []int) {
+	return file_weather_message_proto_rawDescGZIP(), []int{0}
+}
+
+type AlertComparison int32
+
+const (
+	AlertComparison_ALERT_COMPARISON_UNSPECIFIED AlertComparison = 0
+	AlertComparison_ALERT_COMPARISON_ABOVE       AlertComparison = 1
+	AlertComparison_ALERT_COMPARISON_BELOW       AlertComparison = 2
+)
+
+// Enum value maps for AlertComparison.
+var (
+	AlertComparison_name = map[int32]string{
+		0: "ALERT_COMPARISON_UNSPECIFIED",
+		1: "ALERT_COMPARISON_ABOVE",
+		2: "ALERT_COMPARISON_BELOW",
+	}
+	AlertComparison_value = map[string]int32{
+		"ALERT_COMPARISON_UNSPECIFIED": 0,
+		"ALERT_COMPARISON_ABOVE":       1,
+		"ALERT_COMPARISON_BELOW":       2,
+	}
+)
+
+func (x AlertComparison) Enum() *AlertComparison {
+	p := new(AlertComparison)
+	*p = x
+	return p
+}
+
+func (x AlertComparison) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AlertComparison) Descriptor() protoreflect.EnumDescriptor {
+	return file_weather_message_proto_enumTypes[1].Descriptor()
+}
+
+func (AlertComparison) Type() protoreflect.EnumType {
+	return &file_weather_message_proto_enumTypes[1]
+}
+
+func (x AlertComparison) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AlertComparison.Descriptor instead.
+func (AlertComparison) EnumDescriptor() ([]byte, []int) {
+	return file_weather_message_proto_rawDescGZIP(), []int{1}
+}
+
+type AlertState int32
+
+const (
+	AlertState_ALERT_STATE_UNSPECIFIED AlertState = 0
+	AlertState_ALERT_STATE_FIRING      AlertState = 1
+	AlertState_ALERT_STATE_RESOLVED    AlertState = 2
+)
+
+// Enum value maps for AlertState.
+var (
+	AlertState_name = map[int32]string{
+		0: "ALERT_STATE_UNSPECIFIED",
+		1: "ALERT_STATE_FIRING",
+		2: "ALERT_STATE_RESOLVED",
+	}
+	AlertState_value = map[string]int32{
+		"ALERT_STATE_UNSPECIFIED": 0,
+		"ALERT_STATE_FIRING":      1,
+		"ALERT_STATE_RESOLVED":    2,
+	}
+)
+
+func (x AlertState) Enum() *AlertState {
+	p := new(AlertState)
+	*p = x
+	return p
+}
+
+func (x AlertState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AlertState) Descriptor() protoreflect.EnumDescriptor {
+	return file_weather_message_proto_enumTypes[2].Descriptor()
+}
+
+func (AlertState) Type() protoreflect.EnumType {
+	return &file_weather_message_proto_enumTypes[2]
+}
+
+func (x AlertState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AlertState.Descriptor instead.
+func (AlertState) EnumDescriptor() ([]byte, []int) {
+	return file_weather_message_proto_rawDescGZIP(), []int{2}
+}
+
+type HistoryResolution int32
+
+const (
+	// HISTORY_RESOLUTION_RAW returns every stored observation
+	HistoryResolution_HISTORY_RESOLUTION_RAW    HistoryResolution = 0
+	HistoryResolution_HISTORY_RESOLUTION_HOURLY HistoryResolution = 1
+	// HISTORY_RESOLUTION_DAILY buckets are UTC days
+	HistoryResolution_HISTORY_RESOLUTION_DAILY HistoryResolution = 2
+)
+
+// Enum value maps for HistoryResolution.
+var (
+	HistoryResolution_name = map[int32]string{
+		0: "HISTORY_RESOLUTION_RAW",
+		1: "HISTORY_RESOLUTION_HOURLY",
+		2: "HISTORY_RESOLUTION_DAILY",
+	}
+	HistoryResolution_value = map[string]int32{
+		"HISTORY_RESOLUTION_RAW":    0,
+		"HISTORY_RESOLUTION_HOURLY": 1,
+		"HISTORY_RESOLUTION_DAILY":  2,
+	}
+)
+
+func (x HistoryResolution) Enum() *HistoryResolution {
+	p := new(HistoryResolution)
+	*p = x
+	return p
+}
+
+func (x HistoryResolution) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HistoryResolution) Descriptor() protoreflect.EnumDescriptor {
+	return file_weather_message_proto_enumTypes[3].Descriptor()
+}
+
+func (HistoryResolution) Type() protoreflect.EnumType {
+	return &file_weather_message_proto_enumTypes[3]
+}
+
+func (x HistoryResolution) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HistoryResolution.Descriptor instead.
+func (HistoryResolution) EnumDescriptor() ([]byte, []int) {
+	return file_weather_message_proto_rawDescGZIP(), []int{3}
+}
+
 type WeatherRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	City string `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	City string `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	// interval between two updates, the server default when unset. Intervals
+	// shorter than the server minimum are raised to it.
+	Interval *durationpb.Duration `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`
+}
+
+func (x *WeatherRequest) Reset() {
+	*x = WeatherRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_weather_message_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WeatherRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WeatherRequest) ProtoMessage() {}
+
+func (x *WeatherRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_message_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WeatherRequest.ProtoReflect.Descriptor instead.
+func (*WeatherRequest) Descriptor() ([]byte, []int) {
+	return file_weather_message_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WeatherRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *WeatherRequest) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+type WeatherResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	City string `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	// weather is the condition, such as "sunny" or "rain"
+	Weather            string  `protobuf:"bytes,2,opt,name=weather,proto3" json:"weather,omitempty"`
+	TemperatureCelsius float64 `protobuf:"fixed64,5,opt,name=temperatureCelsius,proto3" json:"temperatureCelsius,omitempty"`
+	// humidityPercent is the relative humidity, from 0 to 100
+	HumidityPercent float64 `protobuf:"fixed64,6,opt,name=humidityPercent,proto3" json:"humidityPercent,omitempty"`
+	WindSpeedKph    float64 `protobuf:"fixed64,7,opt,name=windSpeedKph,proto3" json:"windSpeedKph,omitempty"`
+	// windDirectionDegrees is where the wind blows from, clockwise from north
+	WindDirectionDegrees float64                `protobuf:"fixed64,8,opt,name=windDirectionDegrees,proto3" json:"windDirectionDegrees,omitempty"`
+	ObservedAt           *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=observedAt,proto3" json:"observedAt,omitempty"`
+}
+
+func (x *WeatherResponse) Reset() {
+	*x = WeatherResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_weather_message_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WeatherResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WeatherResponse) ProtoMessage() {}
+
+func (x *WeatherResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_message_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WeatherResponse.ProtoReflect.Descriptor instead.
+func (*WeatherResponse) Descriptor() ([]byte, []int) {
+	return file_weather_message_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WeatherResponse) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *WeatherResponse) GetWeather() string {
+	if x != nil {
+		return x.Weather
+	}
+	return ""
+}
+
+func (x *WeatherResponse) GetTemperatureCelsius() float64 {
+	if x != nil {
+		return x.TemperatureCelsius
+	}
+	return 0
+}
+
+func (x *WeatherResponse) GetHumidityPercent() float64 {
+	if x != nil {
+		return x.HumidityPercent
+	}
+	return 0
+}
+
+func (x *WeatherResponse) GetWindSpeedKph() float64 {
+	if x != nil {
+		return x.WindSpeedKph
+	}
+	return 0
+}
+
+func (x *WeatherResponse) GetWindDirectionDegrees() float64 {
+	if x != nil {
+		return x.WindDirectionDegrees
+	}
+	return 0
+}
+
+func (x *WeatherResponse) GetObservedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ObservedAt
+	}
+	return nil
+}
+
+// SubscribeWeatherRequest changes a weather subscription: the interval is
+// applied first, then the removals, then the additions. Fields left empty
+// change nothing.
+type SubscribeWeatherRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AddCities    []string             `protobuf:"bytes,1,rep,name=addCities,proto3" json:"addCities,omitempty"`
+	RemoveCities []string             `protobuf:"bytes,2,rep,name=removeCities,proto3" json:"removeCities,omitempty"`
+	Interval     *durationpb.Duration `protobuf:"bytes,3,opt,name=interval,proto3" json:"interval,omitempty"`
+	// addRules adds alert rules, replacing the rules with the same id. A rule
+	// is evaluated against the updates of its city, so it only fires while
+	// the city is watched.
+	AddRules []*AlertRule `protobuf:"bytes,4,rep,name=addRules,proto3" json:"addRules,omitempty"`
+	// removeRules removes the rules with these ids
+	RemoveRules []string `protobuf:"bytes,5,rep,name=removeRules,proto3" json:"removeRules,omitempty"`
+}
+
+func (x *SubscribeWeatherRequest) Reset() {
+	*x = SubscribeWeatherRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_weather_message_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeWeatherRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeWeatherRequest) ProtoMessage() {}
+
+func (x *SubscribeWeatherRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_message_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeWeatherRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeWeatherRequest) Descriptor() ([]byte, []int) {
+	return file_weather_message_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SubscribeWeatherRequest) GetAddCities() []string {
+	if x != nil {
+		return x.AddCities
+	}
+	return nil
+}
+
+func (x *SubscribeWeatherRequest) GetRemoveCities() []string {
+	if x != nil {
+		return x.RemoveCities
+	}
+	return nil
+}
+
+func (x *SubscribeWeatherRequest) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *SubscribeWeatherRequest) GetAddRules() []*AlertRule {
+	if x != nil {
+		return x.AddRules
+	}
+	return nil
+}
+
+func (x *SubscribeWeatherRequest) GetRemoveRules() []string {
+	if x != nil {
+		return x.RemoveRules
+	}
+	return nil
+}
+
+type SubscribeWeatherResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Event:
+	//	*SubscribeWeatherResponse_Update
+	//	*SubscribeWeatherResponse_Alert
+	//	*SubscribeWeatherResponse_Error
+	Event isSubscribeWeatherResponse_Event `protobuf_oneof:"event"`
+}
+
+func (x *SubscribeWeatherResponse) Reset() {
+	*x = SubscribeWeatherResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_weather_message_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeWeatherResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeWeatherResponse) ProtoMessage() {}
+
+func (x *SubscribeWeatherResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_message_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeWeatherResponse.ProtoReflect.Descriptor instead.
+func (*SubscribeWeatherResponse) Descriptor() ([]byte, []int) {
+	return file_weather_message_proto_rawDescGZIP(), []int{3}
+}
+
+func (m *SubscribeWeatherResponse) GetEvent() isSubscribeWeatherResponse_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (x *SubscribeWeatherResponse) GetUpdate() *WeatherResponse {
+	if x, ok := x.GetEvent().(*SubscribeWeatherResponse_Update); ok {
+		return x.Update
+	}
+	return nil
+}
+
+func (x *SubscribeWeatherResponse) GetAlert() *WeatherAlert {
+	if x, ok := x.GetEvent().(*SubscribeWeatherResponse_Alert); ok {
+		return x.Alert
+	}
+	return nil
+}
+
+func (x *SubscribeWeatherResponse) GetError() *CityError {
+	if x, ok := x.GetEvent().(*SubscribeWeatherResponse_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+type isSubscribeWeatherResponse_Event interface {
+	isSubscribeWeatherResponse_Event()
+}
+
+type SubscribeWeatherResponse_Update struct {
+	Update *WeatherResponse `protobuf:"bytes,1,opt,name=update,proto3,oneof"`
+}
+
+type SubscribeWeatherResponse_Alert struct {
+	Alert *WeatherAlert `protobuf:"bytes,2,opt,name=alert,proto3,oneof"`
+}
+
+type SubscribeWeatherResponse_Error struct {
+	Error *CityError `protobuf:"bytes,3,opt,name=error,proto3,oneof"`
+}
+
+func (*SubscribeWeatherResponse_Update) isSubscribeWeatherResponse_Event() {}
+
+func (*SubscribeWeatherResponse_Alert) isSubscribeWeatherResponse_Event() {}
+
+func (*SubscribeWeatherResponse_Error) isSubscribeWeatherResponse_Event() {}
+
+// CityError is sent when the provider has no weather for a city the client
+// added. The city is no longer watched, the rest of the subscription goes on.
+type CityError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	City    string `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *CityError) Reset() {
+	*x = CityError{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_weather_message_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CityError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CityError) ProtoMessage() {}
+
+func (x *CityError) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_message_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CityError.ProtoReflect.Descriptor instead.
+func (*CityError) Descriptor() ([]byte, []int) {
+	return file_weather_message_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CityError) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *CityError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// AlertRule fires when a metric of a city is above or below a threshold,
+// such as the temperature of London above 30.
+type AlertRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         string          `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	City       string          `protobuf:"bytes,2,opt,name=city,proto3" json:"city,omitempty"`
+	Metric     WeatherMetric   `protobuf:"varint,3,opt,name=metric,proto3,enum=weather.WeatherMetric" json:"metric,omitempty"`
+	Comparison AlertComparison `protobuf:"varint,4,opt,name=comparison,proto3,enum=weather.AlertComparison" json:"comparison,omitempty"`
+	// threshold is in the unit of the metric: Celsius, percent or km/h
+	Threshold float64 `protobuf:"fixed64,5,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	// debounce is how long the condition must hold, or stop holding, before
+	// the rule fires or resolves. Updates are apart by the subscription
+	// interval, so a debounce shorter than it acts on the first update.
+	Debounce *durationpb.Duration `protobuf:"bytes,6,opt,name=debounce,proto3" json:"debounce,omitempty"`
+}
+
+func (x *AlertRule) Reset() {
+	*x = AlertRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_weather_message_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AlertRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertRule) ProtoMessage() {}
+
+func (x *AlertRule) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_message_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertRule.ProtoReflect.Descriptor instead.
+func (*AlertRule) Descriptor() ([]byte, []int) {
+	return file_weather_message_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AlertRule) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AlertRule) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *AlertRule) GetMetric() WeatherMetric {
+	if x != nil {
+		return x.Metric
+	}
+	return WeatherMetric_WEATHER_METRIC_UNSPECIFIED
+}
+
+func (x *AlertRule) GetComparison() AlertComparison {
+	if x != nil {
+		return x.Comparison
+	}
+	return AlertComparison_ALERT_COMPARISON_UNSPECIFIED
+}
+
+func (x *AlertRule) GetThreshold() float64 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *AlertRule) GetDebounce() *durationpb.Duration {
+	if x != nil {
+		return x.Debounce
+	}
+	return nil
+}
+
+// WeatherAlert is sent when a rule starts firing or resolves
+type WeatherAlert struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RuleId     string          `protobuf:"bytes,1,opt,name=ruleId,proto3" json:"ruleId,omitempty"`
+	City       string          `protobuf:"bytes,2,opt,name=city,proto3" json:"city,omitempty"`
+	State      AlertState      `protobuf:"varint,3,opt,name=state,proto3,enum=weather.AlertState" json:"state,omitempty"`
+	Metric     WeatherMetric   `protobuf:"varint,4,opt,name=metric,proto3,enum=weather.WeatherMetric" json:"metric,omitempty"`
+	Comparison AlertComparison `protobuf:"varint,5,opt,name=comparison,proto3,enum=weather.AlertComparison" json:"comparison,omitempty"`
+	Threshold  float64         `protobuf:"fixed64,6,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	// value is the metric in the update that changed the state
+	Value      float64                `protobuf:"fixed64,7,opt,name=value,proto3" json:"value,omitempty"`
+	ObservedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=observedAt,proto3" json:"observedAt,omitempty"`
+}
+
+func (x *WeatherAlert) Reset() {
+	*x = WeatherAlert{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_weather_message_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WeatherAlert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WeatherAlert) ProtoMessage() {}
+
+func (x *WeatherAlert) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_message_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WeatherAlert.ProtoReflect.Descriptor instead.
+func (*WeatherAlert) Descriptor() ([]byte, []int) {
+	return file_weather_message_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *WeatherAlert) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *WeatherAlert) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *WeatherAlert) GetState() AlertState {
+	if x != nil {
+		return x.State
+	}
+	return AlertState_ALERT_STATE_UNSPECIFIED
+}
+
+func (x *WeatherAlert) GetMetric() WeatherMetric {
+	if x != nil {
+		return x.Metric
+	}
+	return WeatherMetric_WEATHER_METRIC_UNSPECIFIED
+}
+
+func (x *WeatherAlert) GetComparison() AlertComparison {
+	if x != nil {
+		return x.Comparison
+	}
+	return AlertComparison_ALERT_COMPARISON_UNSPECIFIED
+}
+
+func (x *WeatherAlert) GetThreshold() float64 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *WeatherAlert) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *WeatherAlert) GetObservedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ObservedAt
+	}
+	return nil
+}
+
+// WeatherHistoryRequest asks for the stored weather of a city from from,
+// inclusive, to to, exclusive. to defaults to now and from to a day before
+// to. Raw ranges holding too many observations fail with INVALID_ARGUMENT,
+// ask for buckets instead.
+type WeatherHistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	City       string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	From       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Resolution HistoryResolution      `protobuf:"varint,4,opt,name=resolution,proto3,enum=weather.HistoryResolution" json:"resolution,omitempty"`
 }
 
-func (x *WeatherRequest) Reset() {
-	*x = WeatherRequest{}
+func (x *WeatherHistoryRequest) Reset() {
+	*x = WeatherHistoryRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_weather_message_proto_msgTypes[0]
+		mi := &file_weather_message_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WeatherRequest) String() string {
+func (x *WeatherHistoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WeatherRequest) ProtoMessage() {}
+func (*WeatherHistoryRequest) ProtoMessage() {}
 
-func (x *WeatherRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_weather_message_proto_msgTypes[0]
+func (x *WeatherHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_message_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -55,46 +855,66 @@ func (x *WeatherRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WeatherRequest.ProtoReflect.Descriptor instead.
-func (*WeatherRequest) Descriptor() ([]byte, []int) {
-	return file_weather_message_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use WeatherHistoryRequest.ProtoReflect.Descriptor instead.
+func (*WeatherHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_weather_message_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *WeatherRequest) GetCity() string {
+func (x *WeatherHistoryRequest) GetCity() string {
 	if x != nil {
 		return x.City
 	}
 	return ""
 }
 
-type WeatherResponse struct {
+func (x *WeatherHistoryRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *WeatherHistoryRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *WeatherHistoryRequest) GetResolution() HistoryResolution {
+	if x != nil {
+		return x.Resolution
+	}
+	return HistoryResolution_HISTORY_RESOLUTION_RAW
+}
+
+type MetricSummary struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	City        string `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
-	Weather     string `protobuf:"bytes,2,opt,name=weather,proto3" json:"weather,omitempty"`
-	Temperature string `protobuf:"bytes,3,opt,name=temperature,proto3" json:"temperature,omitempty"`
-	Update      string `protobuf:"bytes,4,opt,name=update,proto3" json:"update,omitempty"`
+	Min float64 `protobuf:"fixed64,1,opt,name=min,proto3" json:"min,omitempty"`
+	Max float64 `protobuf:"fixed64,2,opt,name=max,proto3" json:"max,omitempty"`
+	Avg float64 `protobuf:"fixed64,3,opt,name=avg,proto3" json:"avg,omitempty"`
 }
 
-func (x *WeatherResponse) Reset() {
-	*x = WeatherResponse{}
+func (x *MetricSummary) Reset() {
+	*x = MetricSummary{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_weather_message_proto_msgTypes[1]
+		mi := &file_weather_message_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WeatherResponse) String() string {
+func (x *MetricSummary) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WeatherResponse) ProtoMessage() {}
+func (*MetricSummary) ProtoMessage() {}
 
-func (x *WeatherResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_weather_message_proto_msgTypes[1]
+func (x *MetricSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_message_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -105,56 +925,352 @@ func (x *WeatherResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WeatherResponse.ProtoReflect.Descriptor instead.
-func (*WeatherResponse) Descriptor() ([]byte, []int) {
-	return file_weather_message_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use MetricSummary.ProtoReflect.Descriptor instead.
+func (*MetricSummary) Descriptor() ([]byte, []int) {
+	return file_weather_message_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *WeatherResponse) GetCity() string {
+func (x *MetricSummary) GetMin() float64 {
 	if x != nil {
-		return x.City
+		return x.Min
 	}
-	return ""
+	return 0
 }
 
-func (x *WeatherResponse) GetWeather() string {
+func (x *MetricSummary) GetMax() float64 {
 	if x != nil {
-		return x.Weather
+		return x.Max
 	}
-	return ""
+	return 0
 }
 
-func (x *WeatherResponse) GetTemperature() string {
+func (x *MetricSummary) GetAvg() float64 {
 	if x != nil {
-		return x.Temperature
+		return x.Avg
 	}
-	return ""
+	return 0
+}
+
+// WeatherBucket summarizes the observations of an hour or a day. Buckets
+// without observations are left out.
+type WeatherBucket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start              *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	Count              int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	TemperatureCelsius *MetricSummary         `protobuf:"bytes,3,opt,name=temperatureCelsius,proto3" json:"temperatureCelsius,omitempty"`
+	HumidityPercent    *MetricSummary         `protobuf:"bytes,4,opt,name=humidityPercent,proto3" json:"humidityPercent,omitempty"`
+	WindSpeedKph       *MetricSummary         `protobuf:"bytes,5,opt,name=windSpeedKph,proto3" json:"windSpeedKph,omitempty"`
+}
+
+func (x *WeatherBucket) Reset() {
+	*x = WeatherBucket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_weather_message_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WeatherBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WeatherBucket) ProtoMessage() {}
+
+func (x *WeatherBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_message_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WeatherBucket.ProtoReflect.Descriptor instead.
+func (*WeatherBucket) Descriptor() ([]byte, []int) {
+	return file_weather_message_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *WeatherResponse) GetUpdate() string {
+func (x *WeatherBucket) GetStart() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Update
+		return x.Start
+	}
+	return nil
+}
+
+func (x *WeatherBucket) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *WeatherBucket) GetTemperatureCelsius() *MetricSummary {
+	if x != nil {
+		return x.TemperatureCelsius
+	}
+	return nil
+}
+
+func (x *WeatherBucket) GetHumidityPercent() *MetricSummary {
+	if x != nil {
+		return x.HumidityPercent
+	}
+	return nil
+}
+
+func (x *WeatherBucket) GetWindSpeedKph() *MetricSummary {
+	if x != nil {
+		return x.WindSpeedKph
+	}
+	return nil
+}
+
+// WeatherHistoryResponse holds the observations for the raw resolution and
+// the buckets for the others, oldest first
+type WeatherHistoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	City         string             `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Observations []*WeatherResponse `protobuf:"bytes,2,rep,name=observations,proto3" json:"observations,omitempty"`
+	Buckets      []*WeatherBucket   `protobuf:"bytes,3,rep,name=buckets,proto3" json:"buckets,omitempty"`
+}
+
+func (x *WeatherHistoryResponse) Reset() {
+	*x = WeatherHistoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_weather_message_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WeatherHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WeatherHistoryResponse) ProtoMessage() {}
+
+func (x *WeatherHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_message_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WeatherHistoryResponse.ProtoReflect.Descriptor instead.
+func (*WeatherHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_weather_message_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WeatherHistoryResponse) GetCity() string {
+	if x != nil {
+		return x.City
 	}
 	return ""
 }
 
+func (x *WeatherHistoryResponse) GetObservations() []*WeatherResponse {
+	if x != nil {
+		return x.Observations
+	}
+	return nil
+}
+
+func (x *WeatherHistoryResponse) GetBuckets() []*WeatherBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
 var File_weather_message_proto protoreflect.FileDescriptor
 
 var file_weather_message_proto_rawDesc = []byte{
 	0x0a, 0x15, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
 	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72,
-	0x22, 0x24, 0x0a, 0x0e, 0x57, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x63, 0x69, 0x74, 0x79, 0x22, 0x79, 0x0a, 0x0f, 0x57, 0x65, 0x61, 0x74, 0x68, 0x65,
-	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x69, 0x74,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x69, 0x74, 0x79, 0x12, 0x18, 0x0a,
-	0x07, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65,
-	0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x65,
-	0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2e, 0x2f, 0x70, 0x62, 0x3b, 0x70, 0x62, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x5b, 0x0a, 0x0e, 0x57, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x63, 0x69, 0x74, 0x79, 0x12, 0x35, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0xb9,
+	0x02, 0x0a, 0x0f, 0x57, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x63, 0x69, 0x74, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72,
+	0x12, 0x2e, 0x0a, 0x12, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x43,
+	0x65, 0x6c, 0x73, 0x69, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x12, 0x74, 0x65,
+	0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x43, 0x65, 0x6c, 0x73, 0x69, 0x75, 0x73,
+	0x12, 0x28, 0x0a, 0x0f, 0x68, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x65, 0x72, 0x63,
+	0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x68, 0x75, 0x6d, 0x69, 0x64,
+	0x69, 0x74, 0x79, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x77, 0x69,
+	0x6e, 0x64, 0x53, 0x70, 0x65, 0x65, 0x64, 0x4b, 0x70, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0c, 0x77, 0x69, 0x6e, 0x64, 0x53, 0x70, 0x65, 0x65, 0x64, 0x4b, 0x70, 0x68, 0x12, 0x32,
+	0x0a, 0x14, 0x77, 0x69, 0x6e, 0x64, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44,
+	0x65, 0x67, 0x72, 0x65, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x14, 0x77, 0x69,
+	0x6e, 0x64, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x67, 0x72, 0x65,
+	0x65, 0x73, 0x12, 0x3a, 0x0a, 0x0a, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x41, 0x74,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x0a, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x41, 0x74, 0x4a, 0x04,
+	0x08, 0x03, 0x10, 0x04, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x22, 0xe4, 0x01, 0x0a, 0x17, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x57, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x43, 0x69, 0x74,
+	0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x64, 0x64, 0x43, 0x69,
+	0x74, 0x69, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x69,
+	0x74, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x43, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x35, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12,
+	0x2e, 0x0a, 0x08, 0x61, 0x64, 0x64, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x41, 0x6c, 0x65, 0x72,
+	0x74, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x08, 0x61, 0x64, 0x64, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12,
+	0x20, 0x0a, 0x0b, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x75, 0x6c, 0x65,
+	0x73, 0x22, 0xb2, 0x01, 0x0a, 0x18, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x57,
+	0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32,
+	0x0a, 0x06, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x57, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x06, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x12, 0x2d, 0x0a, 0x05, 0x61, 0x6c, 0x65, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x57, 0x65, 0x61, 0x74,
+	0x68, 0x65, 0x72, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x48, 0x00, 0x52, 0x05, 0x61, 0x6c, 0x65, 0x72,
+	0x74, 0x12, 0x2a, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x43, 0x69, 0x74, 0x79, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x42, 0x07, 0x0a,
+	0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x39, 0x0a, 0x09, 0x43, 0x69, 0x74, 0x79, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x63, 0x69, 0x74, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0xee, 0x01, 0x0a, 0x09, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x52, 0x75, 0x6c, 0x65, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x63, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63,
+	0x69, 0x74, 0x79, 0x12, 0x2e, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x57, 0x65,
+	0x61, 0x74, 0x68, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x52, 0x06, 0x6d, 0x65, 0x74,
+	0x72, 0x69, 0x63, 0x12, 0x38, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x69, 0x73, 0x6f,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65,
+	0x72, 0x2e, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x69, 0x73, 0x6f,
+	0x6e, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x69, 0x73, 0x6f, 0x6e, 0x12, 0x1c, 0x0a,
+	0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x35, 0x0a, 0x08, 0x64,
+	0x65, 0x62, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x64, 0x65, 0x62, 0x6f, 0x75, 0x6e,
+	0x63, 0x65, 0x22, 0xbf, 0x02, 0x0a, 0x0c, 0x57, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x41, 0x6c,
+	0x65, 0x72, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x75, 0x6c, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x75, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x63,
+	0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x69, 0x74, 0x79, 0x12,
+	0x29, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13,
+	0x2e, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x6d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x77, 0x65, 0x61,
+	0x74, 0x68, 0x65, 0x72, 0x2e, 0x57, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x38, 0x0a, 0x0a, 0x63, 0x6f,
+	0x6d, 0x70, 0x61, 0x72, 0x69, 0x73, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18,
+	0x2e, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x43, 0x6f,
+	0x6d, 0x70, 0x61, 0x72, 0x69, 0x73, 0x6f, 0x6e, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72,
+	0x69, 0x73, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c,
+	0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f,
+	0x6c, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x3a, 0x0a, 0x0a, 0x6f, 0x62, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x64, 0x41, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x64, 0x41, 0x74, 0x22, 0xc3, 0x01, 0x0a, 0x15, 0x57, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72,
+	0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x63, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x69,
+	0x74, 0x79, 0x12, 0x2e, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x66, 0x72,
+	0x6f, 0x6d, 0x12, 0x2a, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x3a,
+	0x0a, 0x0a, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x48, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a,
+	0x72, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x45, 0x0a, 0x0d, 0x4d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6d,
+	0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x10, 0x0a,
+	0x03, 0x6d, 0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x12,
+	0x10, 0x0a, 0x03, 0x61, 0x76, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x61, 0x76,
+	0x67, 0x22, 0x9d, 0x02, 0x0a, 0x0d, 0x57, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x42, 0x75, 0x63,
+	0x6b, 0x65, 0x74, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x46, 0x0a, 0x12, 0x74,
+	0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x43, 0x65, 0x6c, 0x73, 0x69, 0x75,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65,
+	0x72, 0x2e, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52,
+	0x12, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x43, 0x65, 0x6c, 0x73,
+	0x69, 0x75, 0x73, 0x12, 0x40, 0x0a, 0x0f, 0x68, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50,
+	0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x77,
+	0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x53, 0x75, 0x6d,
+	0x6d, 0x61, 0x72, 0x79, 0x52, 0x0f, 0x68, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x50, 0x65,
+	0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x3a, 0x0a, 0x0c, 0x77, 0x69, 0x6e, 0x64, 0x53, 0x70, 0x65,
+	0x65, 0x64, 0x4b, 0x70, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x77, 0x65,
+	0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x53, 0x75, 0x6d, 0x6d,
+	0x61, 0x72, 0x79, 0x52, 0x0c, 0x77, 0x69, 0x6e, 0x64, 0x53, 0x70, 0x65, 0x65, 0x64, 0x4b, 0x70,
+	0x68, 0x22, 0x9c, 0x01, 0x0a, 0x16, 0x57, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x48, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x63, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x69, 0x74, 0x79,
+	0x12, 0x3c, 0x0a, 0x0c, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72,
+	0x2e, 0x57, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x52, 0x0c, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x30,
+	0x0a, 0x07, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x57, 0x65, 0x61, 0x74, 0x68, 0x65,
+	0x72, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x07, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x2a, 0x8b, 0x01, 0x0a, 0x0d, 0x57, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x12, 0x1e, 0x0a, 0x1a, 0x57, 0x45, 0x41, 0x54, 0x48, 0x45, 0x52, 0x5f, 0x4d, 0x45,
+	0x54, 0x52, 0x49, 0x43, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x1e, 0x0a, 0x1a, 0x57, 0x45, 0x41, 0x54, 0x48, 0x45, 0x52, 0x5f, 0x4d, 0x45,
+	0x54, 0x52, 0x49, 0x43, 0x5f, 0x54, 0x45, 0x4d, 0x50, 0x45, 0x52, 0x41, 0x54, 0x55, 0x52, 0x45,
+	0x10, 0x01, 0x12, 0x1b, 0x0a, 0x17, 0x57, 0x45, 0x41, 0x54, 0x48, 0x45, 0x52, 0x5f, 0x4d, 0x45,
+	0x54, 0x52, 0x49, 0x43, 0x5f, 0x48, 0x55, 0x4d, 0x49, 0x44, 0x49, 0x54, 0x59, 0x10, 0x02, 0x12,
+	0x1d, 0x0a, 0x19, 0x57, 0x45, 0x41, 0x54, 0x48, 0x45, 0x52, 0x5f, 0x4d, 0x45, 0x54, 0x52, 0x49,
+	0x43, 0x5f, 0x57, 0x49, 0x4e, 0x44, 0x5f, 0x53, 0x50, 0x45, 0x45, 0x44, 0x10, 0x03, 0x2a, 0x6b,
+	0x0a, 0x0f, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x69, 0x73, 0x6f,
+	0x6e, 0x12, 0x20, 0x0a, 0x1c, 0x41, 0x4c, 0x45, 0x52, 0x54, 0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x41,
+	0x52, 0x49, 0x53, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x41, 0x4c, 0x45, 0x52, 0x54, 0x5f, 0x43, 0x4f, 0x4d,
+	0x50, 0x41, 0x52, 0x49, 0x53, 0x4f, 0x4e, 0x5f, 0x41, 0x42, 0x4f, 0x56, 0x45, 0x10, 0x01, 0x12,
+	0x1a, 0x0a, 0x16, 0x41, 0x4c, 0x45, 0x52, 0x54, 0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x41, 0x52, 0x49,
+	0x53, 0x4f, 0x4e, 0x5f, 0x42, 0x45, 0x4c, 0x4f, 0x57, 0x10, 0x02, 0x2a, 0x5b, 0x0a, 0x0a, 0x41,
+	0x6c, 0x65, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1b, 0x0a, 0x17, 0x41, 0x4c, 0x45,
+	0x52, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x41, 0x4c, 0x45, 0x52, 0x54, 0x5f,
+	0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x46, 0x49, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x18,
+	0x0a, 0x14, 0x41, 0x4c, 0x45, 0x52, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x52, 0x45,
+	0x53, 0x4f, 0x4c, 0x56, 0x45, 0x44, 0x10, 0x02, 0x2a, 0x6c, 0x0a, 0x11, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a,
+	0x16, 0x48, 0x49, 0x53, 0x54, 0x4f, 0x52, 0x59, 0x5f, 0x52, 0x45, 0x53, 0x4f, 0x4c, 0x55, 0x54,
+	0x49, 0x4f, 0x4e, 0x5f, 0x52, 0x41, 0x57, 0x10, 0x00, 0x12, 0x1d, 0x0a, 0x19, 0x48, 0x49, 0x53,
+	0x54, 0x4f, 0x52, 0x59, 0x5f, 0x52, 0x45, 0x53, 0x4f, 0x4c, 0x55, 0x54, 0x49, 0x4f, 0x4e, 0x5f,
+	0x48, 0x4f, 0x55, 0x52, 0x4c, 0x59, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x48, 0x49, 0x53, 0x54,
+	0x4f, 0x52, 0x59, 0x5f, 0x52, 0x45, 0x53, 0x4f, 0x4c, 0x55, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x44,
+	0x41, 0x49, 0x4c, 0x59, 0x10, 0x02, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2e, 0x2f, 0x70, 0x62, 0x3b,
+	0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -169,17 +1285,56 @@ func file_weather_message_proto_rawDescGZIP() []byte {
 	return file_weather_message_proto_rawDescData
 }
 
-var file_weather_message_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_weather_message_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_weather_message_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_weather_message_proto_goTypes = []any{
-	(*WeatherRequest)(nil),  // 0: weather.WeatherRequest
-	(*WeatherResponse)(nil), // 1: weather.WeatherResponse
+	(WeatherMetric)(0),               // 0: weather.WeatherMetric
+	(AlertComparison)(0),             // 1: weather.AlertComparison
+	(AlertState)(0),                  // 2: weather.AlertState
+	(HistoryResolution)(0),           // 3: weather.HistoryResolution
+	(*WeatherRequest)(nil),           // 4: weather.WeatherRequest
+	(*WeatherResponse)(nil),          // 5: weather.WeatherResponse
+	(*SubscribeWeatherRequest)(nil),  // 6: weather.SubscribeWeatherRequest
+	(*SubscribeWeatherResponse)(nil), // 7: weather.SubscribeWeatherResponse
+	(*CityError)(nil),                // 8: weather.CityError
+	(*AlertRule)(nil),                // 9: weather.AlertRule
+	(*WeatherAlert)(nil),             // 10: weather.WeatherAlert
+	(*WeatherHistoryRequest)(nil),    // 11: weather.WeatherHistoryRequest
+	(*MetricSummary)(nil),            // 12: weather.MetricSummary
+	(*WeatherBucket)(nil),            // 13: weather.WeatherBucket
+	(*WeatherHistoryResponse)(nil),   // 14: weather.WeatherHistoryResponse
+	(*durationpb.Duration)(nil),      // 15: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),    // 16: google.protobuf.Timestamp
 }
 var file_weather_message_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	15, // 0: weather.WeatherRequest.interval:type_name -> google.protobuf.Duration
+	16, // 1: weather.WeatherResponse.observedAt:type_name -> google.protobuf.Timestamp
+	15, // 2: weather.SubscribeWeatherRequest.interval:type_name -> google.protobuf.Duration
+	9,  // 3: weather.SubscribeWeatherRequest.addRules:type_name -> weather.AlertRule
+	5,  // 4: weather.SubscribeWeatherResponse.update:type_name -> weather.WeatherResponse
+	10, // 5: weather.SubscribeWeatherResponse.alert:type_name -> weather.WeatherAlert
+	8,  // 6: weather.SubscribeWeatherResponse.error:type_name -> weather.CityError
+	0,  // 7: weather.AlertRule.metric:type_name -> weather.WeatherMetric
+	1,  // 8: weather.AlertRule.comparison:type_name -> weather.AlertComparison
+	15, // 9: weather.AlertRule.debounce:type_name -> google.protobuf.Duration
+	2,  // 10: weather.WeatherAlert.state:type_name -> weather.AlertState
+	0,  // 11: weather.WeatherAlert.metric:type_name -> weather.WeatherMetric
+	1,  // 12: weather.WeatherAlert.comparison:type_name -> weather.AlertComparison
+	16, // 13: weather.WeatherAlert.observedAt:type_name -> google.protobuf.Timestamp
+	16, // 14: weather.WeatherHistoryRequest.from:type_name -> google.protobuf.Timestamp
+	16, // 15: weather.WeatherHistoryRequest.to:type_name -> google.protobuf.Timestamp
+	3,  // 16: weather.WeatherHistoryRequest.resolution:type_name -> weather.HistoryResolution
+	16, // 17: weather.WeatherBucket.start:type_name -> google.protobuf.Timestamp
+	12, // 18: weather.WeatherBucket.temperatureCelsius:type_name -> weather.MetricSummary
+	12, // 19: weather.WeatherBucket.humidityPercent:type_name -> weather.MetricSummary
+	12, // 20: weather.WeatherBucket.windSpeedKph:type_name -> weather.MetricSummary
+	5,  // 21: weather.WeatherHistoryResponse.observations:type_name -> weather.WeatherResponse
+	13, // 22: weather.WeatherHistoryResponse.buckets:type_name -> weather.WeatherBucket
+	23, // [23:23] is the sub-list for method output_type
+	23, // [23:23] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
 }
 
 func init() { file_weather_message_proto_init() }
@@ -212,19 +1367,133 @@ func file_weather_message_proto_init() {
 				return nil
 			}
 		}
+		file_weather_message_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*SubscribeWeatherRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_weather_message_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*SubscribeWeatherResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_weather_message_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*CityError); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_weather_message_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*AlertRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_weather_message_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*WeatherAlert); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_weather_message_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*WeatherHistoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_weather_message_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*MetricSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_weather_message_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*WeatherBucket); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_weather_message_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*WeatherHistoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_weather_message_proto_msgTypes[3].OneofWrappers = []any{
+		(*SubscribeWeatherResponse_Update)(nil),
+		(*SubscribeWeatherResponse_Alert)(nil),
+		(*SubscribeWeatherResponse_Error)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_weather_message_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   2,
+			NumEnums:      4,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_weather_message_proto_goTypes,
 		DependencyIndexes: file_weather_message_proto_depIdxs,
+		EnumInfos:         file_weather_message_proto_enumTypes,
 		MessageInfos:      file_weather_message_proto_msgTypes,
 	}.Build()
 	File_weather_message_proto = out.File
@@ -20,6 +20,8 @@ const _ = grpc.SupportPackageIsVersion8
 
 const (
 	WeatherService_GetWeatherUpdates_FullMethodName = "/weather.WeatherService/GetWeatherUpdates"
+	WeatherService_SubscribeWeather_FullMethodName  = "/weather.WeatherService/SubscribeWeather"
+	WeatherService_GetWeatherHistory_FullMethodName = "/weather.WeatherService/GetWeatherHistory"
 )
 
 // WeatherServiceClient is the client API for WeatherService service.
@@ -27,6 +29,15 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type WeatherServiceClient interface {
 	GetWeatherUpdates(ctx context.Context, in *WeatherRequest, opts ...grpc.CallOption) (WeatherService_GetWeatherUpdatesClient, error)
+	// SubscribeWeather streams the weather of the cities the client adds,
+	// and the alerts of its rules. Each response carries its city. Cities
+	// and rules can be added and removed and the interval changed at any
+	// time; the stream stays open after the client closes its side, until
+	// it cancels.
+	SubscribeWeather(ctx context.Context, opts ...grpc.CallOption) (WeatherService_SubscribeWeatherClient, error)
+	// GetWeatherHistory returns the observations the service made of a city,
+	// for as long as the server retains them
+	GetWeatherHistory(ctx context.Context, in *WeatherHistoryRequest, opts ...grpc.CallOption) (*WeatherHistoryResponse, error)
 }
 
 type weatherServiceClient struct {
@@ -70,11 +81,62 @@ func (x *weatherServiceGetWeatherUpdatesClient) Recv() (*WeatherResponse, error)
 	return m, nil
 }
 
+func (c *weatherServiceClient) SubscribeWeather(ctx context.Context, opts ...grpc.CallOption) (WeatherService_SubscribeWeatherClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &WeatherService_ServiceDesc.Streams[1], WeatherService_SubscribeWeather_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &weatherServiceSubscribeWeatherClient{ClientStream: stream}
+	return x, nil
+}
+
+type WeatherService_SubscribeWeatherClient interface {
+	Send(*SubscribeWeatherRequest) error
+	Recv() (*SubscribeWeatherResponse, error)
+	grpc.ClientStream
+}
+
+type weatherServiceSubscribeWeatherClient struct {
+	grpc.ClientStream
+}
+
+func (x *weatherServiceSubscribeWeatherClient) Send(m *SubscribeWeatherRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *weatherServiceSubscribeWeatherClient) Recv() (*SubscribeWeatherResponse, error) {
+	m := new(SubscribeWeatherResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *weatherServiceClient) GetWeatherHistory(ctx context.Context, in *WeatherHistoryRequest, opts ...grpc.CallOption) (*WeatherHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WeatherHistoryResponse)
+	err := c.cc.Invoke(ctx, WeatherService_GetWeatherHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WeatherServiceServer is the server API for WeatherService service.
 // All implementations must embed UnimplementedWeatherServiceServer
 // for forward compatibility
 type WeatherServiceServer interface {
 	GetWeatherUpdates(*WeatherRequest, WeatherService_GetWeatherUpdatesServer) error
+	// SubscribeWeather streams the weather of the cities the client adds,
+	// and the alerts of its rules. Each response carries its city. Cities
+	// and rules can be added and removed and the interval changed at any
+	// time; the stream stays open after the client closes its side, until
+	// it cancels.
+	SubscribeWeather(WeatherService_SubscribeWeatherServer) error
+	// GetWeatherHistory returns the observations the service made of a city,
+	// for as long as the server retains them
+	GetWeatherHistory(context.Context, *WeatherHistoryRequest) (*WeatherHistoryResponse, error)
 	mustEmbedUnimplementedWeatherServiceServer()
 }
 
@@ -85,6 +147,12 @@ type UnimplementedWeatherServiceServer struct {
 func (UnimplementedWeatherServiceServer) GetWeatherUpdates(*WeatherRequest, WeatherService_GetWeatherUpdatesServer) error {
 	return status.Errorf(codes.Unimplemented, "method GetWeatherUpdates not implemented")
 }
+func (UnimplementedWeatherServiceServer) SubscribeWeather(WeatherService_SubscribeWeatherServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeWeather not implemented")
+}
+func (UnimplementedWeatherServiceServer) GetWeatherHistory(context.Context, *WeatherHistoryRequest) (*WeatherHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWeatherHistory not implemented")
+}
 func (UnimplementedWeatherServiceServer) mustEmbedUnimplementedWeatherServiceServer() {}
 
 // UnsafeWeatherServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -119,19 +187,74 @@ func (x *weatherServiceGetWeatherUpdatesServer) Send(m *WeatherResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _WeatherService_SubscribeWeather_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(WeatherServiceServer).SubscribeWeather(&weatherServiceSubscribeWeatherServer{ServerStream: stream})
+}
+
+type WeatherService_SubscribeWeatherServer interface {
+	Send(*SubscribeWeatherResponse) error
+	Recv() (*SubscribeWeatherRequest, error)
+	grpc.ServerStream
+}
+
+type weatherServiceSubscribeWeatherServer struct {
+	grpc.ServerStream
+}
+
+func (x *weatherServiceSubscribeWeatherServer) Send(m *SubscribeWeatherResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *weatherServiceSubscribeWeatherServer) Recv() (*SubscribeWeatherRequest, error) {
+	m := new(SubscribeWeatherRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _WeatherService_GetWeatherHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WeatherHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WeatherServiceServer).GetWeatherHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WeatherService_GetWeatherHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WeatherServiceServer).GetWeatherHistory(ctx, req.(*WeatherHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // WeatherService_ServiceDesc is the grpc.ServiceDesc for WeatherService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var WeatherService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "weather.WeatherService",
 	HandlerType: (*WeatherServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetWeatherHistory",
+			Handler:    _WeatherService_GetWeatherHistory_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "GetWeatherUpdates",
 			Handler:       _WeatherService_GetWeatherUpdates_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "SubscribeWeather",
+			Handler:       _WeatherService_SubscribeWeather_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "weather_service.proto",
 }
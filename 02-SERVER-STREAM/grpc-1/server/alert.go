@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"grpc-1/alert"
+	"grpc-1/pb"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var metrics = map[pb.WeatherMetric]alert.Metric{
+	pb.WeatherMetric_WEATHER_METRIC_TEMPERATURE: alert.Temperature,
+	pb.WeatherMetric_WEATHER_METRIC_HUMIDITY:    alert.Humidity,
+	pb.WeatherMetric_WEATHER_METRIC_WIND_SPEED:  alert.WindSpeed,
+}
+
+func toRule(rule *pb.AlertRule) (alert.Rule, error) {
+	metric, ok := metrics[rule.GetMetric()]
+	if !ok {
+		return alert.Rule{}, fmt.Errorf("unknown metric %s", rule.GetMetric())
+	}
+
+	var above bool
+	switch rule.GetComparison() {
+	case pb.AlertComparison_ALERT_COMPARISON_ABOVE:
+		above = true
+	case pb.AlertComparison_ALERT_COMPARISON_BELOW:
+		above = false
+	default:
+		return alert.Rule{}, fmt.Errorf("unknown comparison %s", rule.GetComparison())
+	}
+
+	if rule.GetDebounce() != nil {
+		err := rule.GetDebounce().CheckValid()
+		if err != nil {
+			return alert.Rule{}, fmt.Errorf("invalid debounce: %w", err)
+		}
+	}
+
+	return alert.Rule{
+		ID:        rule.GetId(),
+		City:      rule.GetCity(),
+		Metric:    metric,
+		Above:     above,
+		Threshold: rule.GetThreshold(),
+		Debounce:  rule.GetDebounce().AsDuration(),
+	}, nil
+}
+
+func toAlert(event alert.Event) *pb.WeatherAlert {
+	state := pb.AlertState_ALERT_STATE_RESOLVED
+	if event.Firing {
+		state = pb.AlertState_ALERT_STATE_FIRING
+	}
+	comparison := pb.AlertComparison_ALERT_COMPARISON_BELOW
+	if event.Rule.Above {
+		comparison = pb.AlertComparison_ALERT_COMPARISON_ABOVE
+	}
+	metric := pb.WeatherMetric_WEATHER_METRIC_UNSPECIFIED
+	for protoMetric, ruleMetric := range metrics {
+		if ruleMetric == event.Rule.Metric {
+			metric = protoMetric
+		}
+	}
+
+	return &pb.WeatherAlert{
+		RuleId:     event.Rule.ID,
+		City:       event.Rule.City,
+		State:      state,
+		Metric:     metric,
+		Comparison: comparison,
+		Threshold:  event.Rule.Threshold,
+		Value:      event.Value,
+		ObservedAt: timestamppb.New(event.ObservedAt),
+	}
+}
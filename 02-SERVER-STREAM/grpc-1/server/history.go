@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"grpc-1/history"
+	"grpc-1/pb"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxHistoryObservations is the number of raw observations one
+// GetWeatherHistory call may return
+const maxHistoryObservations = 10000
+
+var bucketSizes = map[pb.HistoryResolution]time.Duration{
+	pb.HistoryResolution_HISTORY_RESOLUTION_HOURLY: time.Hour,
+	pb.HistoryResolution_HISTORY_RESOLUTION_DAILY:  24 * time.Hour,
+}
+
+// GetWeatherHistory returns the stored observations of a city, or their buckets
+func (s *Server) GetWeatherHistory(ctx context.Context, req *pb.WeatherHistoryRequest) (*pb.WeatherHistoryResponse, error) {
+	city := strings.TrimSpace(req.GetCity())
+	if city == "" {
+		return nil, status.Error(codes.InvalidArgument, "city is required")
+	}
+
+	to := time.Now()
+	if req.GetTo() != nil {
+		err := req.GetTo().CheckValid()
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid to: %v", err)
+		}
+		to = req.GetTo().AsTime()
+	}
+	from := to.Add(-24 * time.Hour)
+	if req.GetFrom() != nil {
+		err := req.GetFrom().CheckValid()
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid from: %v", err)
+		}
+		from = req.GetFrom().AsTime()
+	}
+	if !from.Before(to) {
+		return nil, status.Errorf(codes.InvalidArgument, "from %s is not before to %s", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+
+	res := &pb.WeatherHistoryResponse{City: city}
+
+	if req.GetResolution() == pb.HistoryResolution_HISTORY_RESOLUTION_RAW {
+		observations, err := s.history.Observations(ctx, city, from, to, maxHistoryObservations+1)
+		if err != nil {
+			return nil, historyError(ctx, err)
+		}
+		if len(observations) > maxHistoryObservations {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"range holds more than %d observations, narrow it or ask for hourly or daily buckets", maxHistoryObservations)
+		}
+		for _, observation := range observations {
+			res.Observations = append(res.Observations, toWeatherResponse(observation))
+		}
+		return res, nil
+	}
+
+	size, ok := bucketSizes[req.GetResolution()]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown resolution %s", req.GetResolution())
+	}
+	buckets, err := s.history.Buckets(ctx, city, from, to, size)
+	if err != nil {
+		return nil, historyError(ctx, err)
+	}
+	for _, bucket := range buckets {
+		res.Buckets = append(res.Buckets, toBucket(bucket))
+	}
+	return res, nil
+}
+
+func toBucket(bucket history.Bucket) *pb.WeatherBucket {
+	return &pb.WeatherBucket{
+		Start:              timestamppb.New(bucket.Start),
+		Count:              int64(bucket.Count),
+		TemperatureCelsius: toSummary(bucket.TemperatureCelsius),
+		HumidityPercent:    toSummary(bucket.HumidityPercent),
+		WindSpeedKph:       toSummary(bucket.WindSpeedKph),
+	}
+}
+
+func toSummary(summary history.Summary) *pb.MetricSummary {
+	return &pb.MetricSummary{Min: summary.Min, Max: summary.Max, Avg: summary.Avg}
+}
+
+func historyError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return contextError(ctx)
+	}
+	return status.Errorf(codes.Internal, "cannot read weather history: %v", err)
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"grpc-1/pb"
+	"grpc-1/weather"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestGetWeatherHistory(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC)
+	store := newTestStore(t)
+	ctx := context.Background()
+	for i := 0; i < 48; i++ {
+		err := store.Add(ctx, weather.Observation{
+			City:               "London",
+			TemperatureCelsius: float64(i),
+			ObservedAt:         base.Add(time.Duration(i) * 30 * time.Minute),
+		})
+		require.NoError(t, err)
+	}
+	client := newTestClient(t, weather.NewSimulatedProvider(nil, time.Second), store)
+
+	res, err := client.GetWeatherHistory(ctx, &pb.WeatherHistoryRequest{
+		City: "london",
+		From: timestamppb.New(base.Add(time.Hour)),
+		To:   timestamppb.New(base.Add(2 * time.Hour)),
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Observations, 2)
+	require.Equal(t, "London", res.Observations[0].City)
+	require.Equal(t, 2.0, res.Observations[0].TemperatureCelsius)
+	require.Empty(t, res.Buckets)
+
+	res, err = client.GetWeatherHistory(ctx, &pb.WeatherHistoryRequest{
+		City:       "London",
+		From:       timestamppb.New(base),
+		To:         timestamppb.New(base.Add(24 * time.Hour)),
+		Resolution: pb.HistoryResolution_HISTORY_RESOLUTION_HOURLY,
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Buckets, 24)
+	require.Equal(t, base.Add(time.Hour), res.Buckets[1].Start.AsTime())
+	require.Equal(t, int64(2), res.Buckets[1].Count)
+	require.Equal(t, &pb.MetricSummary{Min: 2, Max: 3, Avg: 2.5}, res.Buckets[1].TemperatureCelsius)
+
+	res, err = client.GetWeatherHistory(ctx, &pb.WeatherHistoryRequest{
+		City:       "London",
+		To:         timestamppb.New(base.Add(48 * time.Hour)),
+		Resolution: pb.HistoryResolution_HISTORY_RESOLUTION_DAILY,
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Buckets, 0, "the default range is the day before to")
+
+	res, err = client.GetWeatherHistory(ctx, &pb.WeatherHistoryRequest{
+		City:       "London",
+		From:       timestamppb.New(base),
+		To:         timestamppb.New(base.Add(48 * time.Hour)),
+		Resolution: pb.HistoryResolution_HISTORY_RESOLUTION_DAILY,
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Buckets, 1)
+	require.Equal(t, int64(48), res.Buckets[0].Count)
+
+	// the widest valid timestamps are clamped to what the store can hold
+	res, err = client.GetWeatherHistory(ctx, &pb.WeatherHistoryRequest{
+		City:       "London",
+		From:       timestamppb.New(time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC)),
+		To:         timestamppb.New(time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)),
+		Resolution: pb.HistoryResolution_HISTORY_RESOLUTION_DAILY,
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Buckets, 1)
+	require.Equal(t, int64(48), res.Buckets[0].Count)
+}
+
+func TestGetWeatherHistoryRecordsStreams(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, weather.NewSimulatedProvider(nil, time.Millisecond), newTestStore(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := client.GetWeatherUpdates(ctx, &pb.WeatherRequest{City: "London"})
+	require.NoError(t, err)
+	update, err := stream.Recv()
+	require.NoError(t, err)
+	cancel()
+
+	res, err := client.GetWeatherHistory(context.Background(), &pb.WeatherHistoryRequest{City: "London"})
+	require.NoError(t, err)
+	require.NotEmpty(t, res.Observations)
+	require.Equal(t, update.ObservedAt.AsTime(), res.Observations[0].ObservedAt.AsTime())
+}
+
+func TestGetWeatherHistoryErrors(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, weather.NewSimulatedProvider(nil, time.Second), newTestStore(t))
+	now := time.Now()
+
+	testCases := []struct {
+		name string
+		req  *pb.WeatherHistoryRequest
+	}{
+		{name: "no city", req: &pb.WeatherHistoryRequest{}},
+		{name: "empty range", req: &pb.WeatherHistoryRequest{City: "London", From: timestamppb.New(now), To: timestamppb.New(now)}},
+		{name: "unknown resolution", req: &pb.WeatherHistoryRequest{City: "London", Resolution: 7}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := client.GetWeatherHistory(context.Background(), tc.req)
+			require.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
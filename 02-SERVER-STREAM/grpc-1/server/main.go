@@ -1,7 +1,12 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
+	"grpc-1/history"
 	"grpc-1/pb"
+	"grpc-1/weather"
 	"log"
 	"net"
 	"time"
@@ -11,38 +16,56 @@ import (
 )
 
 func main() {
-	listener, err := net.Listen("tcp", ":50051")
+	address := flag.String("address", ":50051", "address to listen on")
+	providerKind := flag.String("provider", "simulated", "weather provider to use: simulated or http")
+	providerURL := flag.String("provider-url", "", "base url of the http weather provider")
+	interval := flag.Duration("interval", time.Second, "time between two updates when the client asks for none")
+	minInterval := flag.Duration("min-interval", 100*time.Millisecond, "shortest time between two updates a client may ask for")
+	historyPath := flag.String("history-db", "weather.db", "SQLite database file storing the observations")
+	retention := flag.Duration("history-retention", 30*24*time.Hour, "how long observations are kept, 0 keeps them forever")
+	flag.Parse()
+
+	if *interval < *minInterval {
+		log.Fatalf("interval %s is shorter than min-interval %s", *interval, *minInterval)
+	}
+
+	provider, err := newProvider(*providerKind, *providerURL)
+	if err != nil {
+		log.Fatalf("cannot create weather provider: %v", err)
+	}
+
+	store, err := history.NewSQLiteStore(*historyPath)
+	if err != nil {
+		log.Fatalf("cannot open weather history: %v", err)
+	}
+	defer store.Close()
+	if *retention > 0 {
+		go history.Prune(context.Background(), store, *retention, min(*retention/10, time.Hour))
+	}
+
+	listener, err := net.Listen("tcp", *address)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
 	s := grpc.NewServer()
-	pb.RegisterWeatherServiceServer(s, &server{})
+	pb.RegisterWeatherServiceServer(s, NewServer(history.NewRecorder(provider, store), store, *interval, *minInterval))
 
 	// Register reflectio service on grpc server
 	reflection.Register(s)
-	
+
+	log.Printf("serving weather from the %s provider on %s", *providerKind, *address)
 	if err := s.Serve(listener); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
 }
 
-type server struct {
-	pb.UnimplementedWeatherServiceServer
-}
-
-func (s * server) GetWeatherUpdates(req *pb.WeatherRequest, stream pb.WeatherService_GetWeatherUpdatesServer) error {
-	for i := 0; i< 10 ; i++ {
-		update := &pb.WeatherResponse {
-			City : req.GetCity(),
-			Weather: "sunny",
-			Temperature: "30" + string(i),
-			Update: "update #" + string(i),
-		}
-		if err := stream.Send(update); err != nil {
-			return err
-		}
-		time.Sleep(time.Second)
+func newProvider(kind string, url string) (weather.WeatherProvider, error) {
+	switch kind {
+	case "simulated":
+		return weather.NewSimulatedProvider(nil, time.Second), nil
+	case "http":
+		return weather.NewHTTPProvider(url, nil)
 	}
-	return nil
-}
\ No newline at end of file
+	return nil, fmt.Errorf("unknown provider %q", kind)
+}
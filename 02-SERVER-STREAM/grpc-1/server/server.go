@@ -0,0 +1,285 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"grpc-1/alert"
+	"grpc-1/history"
+	"grpc-1/pb"
+	"grpc-1/weather"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxCities and maxRules are the number of cities one subscription may
+// watch and the number of alert rules it may have
+const (
+	maxCities = 50
+	maxRules  = 100
+)
+
+// Server streams the weather of cities
+type Server struct {
+	pb.UnimplementedWeatherServiceServer
+
+	hub     *weather.Hub
+	history *history.SQLiteStore
+	// interval is the time between two updates when the request sets none,
+	// minInterval the shortest interval a request may ask for
+	interval    time.Duration
+	minInterval time.Duration
+}
+
+// NewServer returns a weather service reading the weather from provider and
+// its history from store. Streams watching the same city share its polls.
+func NewServer(provider weather.WeatherProvider, store *history.SQLiteStore, interval time.Duration, minInterval time.Duration) *Server {
+	return &Server{
+		hub:         weather.NewHub(provider),
+		history:     store,
+		interval:    interval,
+		minInterval: minInterval,
+	}
+}
+
+// GetWeatherUpdates sends the weather of the city right away, then once per
+// interval until the client cancels
+func (s *Server) GetWeatherUpdates(req *pb.WeatherRequest, stream pb.WeatherService_GetWeatherUpdatesServer) error {
+	ctx := stream.Context()
+	city := strings.TrimSpace(req.GetCity())
+	if city == "" {
+		return status.Error(codes.InvalidArgument, "city is required")
+	}
+
+	interval, err := s.requestInterval(req.GetInterval())
+	if err != nil {
+		return err
+	}
+
+	sub := s.hub.Subscribe(interval)
+	defer sub.Close()
+	sub.Add(city)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return contextError(ctx)
+		case update := <-sub.Updates():
+			res, err := toResponse(update)
+			if err != nil {
+				return err
+			}
+			if res == nil {
+				// the provider failed this time, wait for the next poll
+				continue
+			}
+			err = stream.Send(res)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// SubscribeWeather streams the weather of the cities the client adds, and
+// the alerts of its rules, until the client cancels
+func (s *Server) SubscribeWeather(stream pb.WeatherService_SubscribeWeatherServer) error {
+	ctx := stream.Context()
+	sub := s.hub.Subscribe(s.interval)
+	defer sub.Close()
+	evaluator := alert.NewEvaluator()
+
+	requests := make(chan *pb.SubscribeWeatherRequest)
+	received := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				received <- err
+				return
+			}
+			select {
+			case requests <- req:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return contextError(ctx)
+		case err := <-received:
+			if err != io.EOF {
+				return err
+			}
+			// the client sends no more changes but still receives updates
+			received = nil
+		case req := <-requests:
+			err := s.applyRequest(sub, evaluator, req)
+			if err != nil {
+				return err
+			}
+		case update := <-sub.Updates():
+			if !sub.Watches(update.City) {
+				// polled before the city was removed
+				continue
+			}
+			err := sendUpdate(stream, sub, evaluator, update)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// sendUpdate sends update followed by the alerts it raises. A city the
+// provider does not know is removed from sub and reported with an error
+// event instead of ending the stream.
+func sendUpdate(stream pb.WeatherService_SubscribeWeatherServer, sub *weather.Subscription, evaluator *alert.Evaluator, update weather.Update) error {
+	if errors.Is(update.Err, weather.ErrUnknownCity) {
+		sub.Remove(update.City)
+		return stream.Send(&pb.SubscribeWeatherResponse{
+			Event: &pb.SubscribeWeatherResponse_Error{Error: &pb.CityError{
+				City:    update.City,
+				Message: fmt.Sprintf("no weather for city %q", update.City),
+			}},
+		})
+	}
+
+	res, err := toResponse(update)
+	if err != nil || res == nil {
+		return err
+	}
+
+	err = stream.Send(&pb.SubscribeWeatherResponse{
+		Event: &pb.SubscribeWeatherResponse_Update{Update: res},
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, event := range evaluator.Evaluate(update.Observation) {
+		err = stream.Send(&pb.SubscribeWeatherResponse{
+			Event: &pb.SubscribeWeatherResponse_Alert{Alert: toAlert(event)},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyRequest changes sub and evaluator as req asks, after checking all of req
+func (s *Server) applyRequest(sub *weather.Subscription, evaluator *alert.Evaluator, req *pb.SubscribeWeatherRequest) error {
+	interval := time.Duration(0)
+	if req.GetInterval() != nil {
+		var err error
+		interval, err = s.requestInterval(req.GetInterval())
+		if err != nil {
+			return err
+		}
+	}
+	for _, city := range req.GetAddCities() {
+		if strings.TrimSpace(city) == "" {
+			return status.Error(codes.InvalidArgument, "cities to add must not be empty")
+		}
+	}
+	rules := []alert.Rule{}
+	for i, rule := range req.GetAddRules() {
+		converted, err := toRule(rule)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid rule %d: %v", i, err)
+		}
+		rules = append(rules, converted)
+	}
+
+	if interval > 0 {
+		sub.SetInterval(interval)
+	}
+	for _, city := range req.GetRemoveCities() {
+		sub.Remove(city)
+	}
+	for _, city := range req.GetAddCities() {
+		if sub.Cities() >= maxCities && !sub.Watches(city) {
+			return status.Errorf(codes.ResourceExhausted, "a subscription watches at most %d cities", maxCities)
+		}
+		sub.Add(city)
+	}
+	for _, id := range req.GetRemoveRules() {
+		evaluator.Remove(id)
+	}
+	for _, rule := range rules {
+		if evaluator.Len() >= maxRules {
+			return status.Errorf(codes.ResourceExhausted, "a subscription has at most %d rules", maxRules)
+		}
+		err := evaluator.Add(rule)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid rule %q: %v", rule.ID, err)
+		}
+	}
+	return nil
+}
+
+func (s *Server) requestInterval(requested *durationpb.Duration) (time.Duration, error) {
+	if requested == nil {
+		return s.interval, nil
+	}
+
+	err := requested.CheckValid()
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid interval: %v", err)
+	}
+	interval := requested.AsDuration()
+	if interval <= 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "interval must be positive, got %s", interval)
+	}
+	if interval < s.minInterval {
+		interval = s.minInterval
+	}
+	return interval, nil
+}
+
+// toResponse returns the response of update. A city the provider does not
+// know fails, other provider errors skip the update with a nil response.
+func toResponse(update weather.Update) (*pb.WeatherResponse, error) {
+	if errors.Is(update.Err, weather.ErrUnknownCity) {
+		return nil, status.Errorf(codes.NotFound, "no weather for city %q", update.City)
+	}
+	if update.Err != nil {
+		log.Printf("cannot get weather of %s: %v", update.City, update.Err)
+		return nil, nil
+	}
+
+	return toWeatherResponse(update.Observation), nil
+}
+
+func toWeatherResponse(observation weather.Observation) *pb.WeatherResponse {
+	return &pb.WeatherResponse{
+		City:                 observation.City,
+		Weather:              observation.Condition,
+		TemperatureCelsius:   observation.TemperatureCelsius,
+		HumidityPercent:      observation.HumidityPercent,
+		WindSpeedKph:         observation.WindSpeedKph,
+		WindDirectionDegrees: observation.WindDirectionDegrees,
+		ObservedAt:           timestamppb.New(observation.ObservedAt),
+	}
+}
+
+func contextError(ctx context.Context) error {
+	switch ctx.Err() {
+	case context.Canceled:
+		return status.Error(codes.Canceled, "request is canceled")
+	case context.DeadlineExceeded:
+		return status.Error(codes.DeadlineExceeded, "deadline is exceeded")
+	}
+	return nil
+}
@@ -0,0 +1,354 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"grpc-1/history"
+	"grpc-1/pb"
+	"grpc-1/weather"
+	"net"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func newTestStore(t *testing.T) *history.SQLiteStore {
+	store, err := history.NewSQLiteStore(filepath.Join(t.TempDir(), "weather.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+// newTestClient serves the observations of provider, recording them in store
+func newTestClient(t *testing.T, provider weather.WeatherProvider, store *history.SQLiteStore) pb.WeatherServiceClient {
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	pb.RegisterWeatherServiceServer(grpcServer, NewServer(history.NewRecorder(provider, store), store, time.Hour, 10*time.Millisecond))
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewWeatherServiceClient(conn)
+}
+
+func TestGetWeatherUpdates(t *testing.T) {
+	t.Parallel()
+
+	// the clock moves one minute per observation
+	now := time.Date(2024, time.July, 1, 12, 0, 0, 0, time.UTC)
+	provider := weather.NewSimulatedProvider(func() time.Time {
+		now = now.Add(time.Minute)
+		return now
+	}, time.Minute)
+	client := newTestClient(t, provider, newTestStore(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := client.GetWeatherUpdates(ctx, &pb.WeatherRequest{
+		City:     "London",
+		Interval: durationpb.New(time.Millisecond),
+	})
+	require.NoError(t, err)
+
+	var previous *pb.WeatherResponse
+	for i := 0; i < 3; i++ {
+		res, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, "London", res.City)
+		if previous != nil {
+			require.Equal(t, time.Minute, res.ObservedAt.AsTime().Sub(previous.ObservedAt.AsTime()))
+		}
+		previous = res
+	}
+
+	cancel()
+	for err == nil {
+		_, err = stream.Recv()
+	}
+	require.Equal(t, codes.Canceled, status.Code(err))
+}
+
+// flakyProvider fails the first poll, then asks provider
+type flakyProvider struct {
+	weather.WeatherProvider
+	failed atomic.Bool
+}
+
+func (provider *flakyProvider) Current(ctx context.Context, city string) (weather.Observation, error) {
+	if provider.failed.CompareAndSwap(false, true) {
+		return weather.Observation{}, errors.New("provider is down")
+	}
+	return provider.WeatherProvider.Current(ctx, city)
+}
+
+func TestGetWeatherUpdatesProviderError(t *testing.T) {
+	t.Parallel()
+
+	provider := &flakyProvider{WeatherProvider: weather.NewSimulatedProvider(nil, time.Millisecond)}
+	client := newTestClient(t, provider, newTestStore(t))
+
+	stream, err := client.GetWeatherUpdates(context.Background(), &pb.WeatherRequest{
+		City:     "London",
+		Interval: durationpb.New(10 * time.Millisecond),
+	})
+	require.NoError(t, err)
+
+	// the failed poll is skipped, the stream goes on with the next ones
+	for i := 0; i < 2; i++ {
+		res, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, "London", res.City)
+	}
+	require.True(t, provider.failed.Load())
+}
+
+func TestGetWeatherUpdatesErrors(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, weather.NewSimulatedProvider(nil, time.Second), newTestStore(t))
+
+	testCases := []struct {
+		name string
+		req  *pb.WeatherRequest
+		code codes.Code
+	}{
+		{name: "no city", req: &pb.WeatherRequest{}, code: codes.InvalidArgument},
+		{name: "negative interval", req: &pb.WeatherRequest{City: "London", Interval: durationpb.New(-time.Second)}, code: codes.InvalidArgument},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			stream, err := client.GetWeatherUpdates(context.Background(), tc.req)
+			require.NoError(t, err)
+			_, err = stream.Recv()
+			require.Equal(t, tc.code, status.Code(err))
+		})
+	}
+}
+
+func TestSubscribeWeather(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, weather.NewSimulatedProvider(nil, time.Millisecond), newTestStore(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := client.SubscribeWeather(ctx)
+	require.NoError(t, err)
+
+	// receive returns the cities of the next n responses
+	receive := func(n int) map[string]int {
+		cities := map[string]int{}
+		for i := 0; i < n; i++ {
+			res, err := stream.Recv()
+			require.NoError(t, err)
+			cities[res.GetUpdate().GetCity()]++
+		}
+		return cities
+	}
+
+	err = stream.Send(&pb.SubscribeWeatherRequest{
+		AddCities: []string{"London", "Paris"},
+		Interval:  durationpb.New(10 * time.Millisecond),
+	})
+	require.NoError(t, err)
+	cities := receive(10)
+	require.Len(t, cities, 2)
+	require.Positive(t, cities["London"])
+	require.Positive(t, cities["Paris"])
+
+	err = stream.Send(&pb.SubscribeWeatherRequest{
+		RemoveCities: []string{"paris"},
+		AddCities:    []string{"Tokyo"},
+	})
+	require.NoError(t, err)
+	// the stream keeps going after the client closes its side
+	require.NoError(t, stream.CloseSend())
+	for {
+		cities = receive(1)
+		if cities["Tokyo"] > 0 {
+			break
+		}
+	}
+	cities = receive(10)
+	require.Zero(t, cities["Paris"])
+	require.Positive(t, cities["Tokyo"])
+
+	cancel()
+	for err == nil {
+		_, err = stream.Recv()
+	}
+	require.Equal(t, codes.Canceled, status.Code(err))
+}
+
+func TestSubscribeWeatherAlerts(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, weather.NewSimulatedProvider(nil, time.Millisecond), newTestStore(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := client.SubscribeWeather(ctx)
+	require.NoError(t, err)
+
+	// humidity is always above -1 and never above 100
+	err = stream.Send(&pb.SubscribeWeatherRequest{
+		AddCities: []string{"London"},
+		Interval:  durationpb.New(10 * time.Millisecond),
+		AddRules: []*pb.AlertRule{
+			{
+				Id:         "always",
+				City:       "london",
+				Metric:     pb.WeatherMetric_WEATHER_METRIC_HUMIDITY,
+				Comparison: pb.AlertComparison_ALERT_COMPARISON_ABOVE,
+				Threshold:  -1,
+			},
+			{
+				Id:         "never",
+				City:       "London",
+				Metric:     pb.WeatherMetric_WEATHER_METRIC_HUMIDITY,
+				Comparison: pb.AlertComparison_ALERT_COMPARISON_ABOVE,
+				Threshold:  100,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	updates := 0
+	alerts := []*pb.WeatherAlert{}
+	for updates < 5 {
+		res, err := stream.Recv()
+		require.NoError(t, err)
+		if res.GetUpdate() != nil {
+			updates++
+			continue
+		}
+		// an alert follows the update that raised it
+		require.Positive(t, updates)
+		alerts = append(alerts, res.GetAlert())
+	}
+
+	require.Len(t, alerts, 1)
+	require.Equal(t, "always", alerts[0].RuleId)
+	require.Equal(t, pb.AlertState_ALERT_STATE_FIRING, alerts[0].State)
+	require.Equal(t, pb.WeatherMetric_WEATHER_METRIC_HUMIDITY, alerts[0].Metric)
+	require.Greater(t, alerts[0].Value, -1.0)
+}
+
+// atlantisProvider has no weather for Atlantis, and asks provider for the
+// other cities
+type atlantisProvider struct {
+	weather.WeatherProvider
+}
+
+func (provider atlantisProvider) Current(ctx context.Context, city string) (weather.Observation, error) {
+	if city == "Atlantis" {
+		return weather.Observation{}, weather.ErrUnknownCity
+	}
+	return provider.WeatherProvider.Current(ctx, city)
+}
+
+func TestSubscribeWeatherUnknownCity(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, atlantisProvider{weather.NewSimulatedProvider(nil, time.Millisecond)}, newTestStore(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := client.SubscribeWeather(ctx)
+	require.NoError(t, err)
+
+	err = stream.Send(&pb.SubscribeWeatherRequest{
+		AddCities: []string{"London", "Atlantis"},
+		Interval:  durationpb.New(10 * time.Millisecond),
+	})
+	require.NoError(t, err)
+
+	// the unknown city is reported once and dropped, London goes on
+	cityErrors := []*pb.CityError{}
+	updates := 0
+	for updates < 5 {
+		res, err := stream.Recv()
+		require.NoError(t, err)
+		if cityError := res.GetError(); cityError != nil {
+			cityErrors = append(cityErrors, cityError)
+			continue
+		}
+		require.Equal(t, "London", res.GetUpdate().GetCity())
+		updates++
+	}
+	require.Len(t, cityErrors, 1)
+	require.Equal(t, "Atlantis", cityErrors[0].City)
+
+	// a single-city stream still fails
+	updatesStream, err := client.GetWeatherUpdates(ctx, &pb.WeatherRequest{City: "Atlantis"})
+	require.NoError(t, err)
+	_, err = updatesStream.Recv()
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestSubscribeWeatherErrors(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, weather.NewSimulatedProvider(nil, time.Second), newTestStore(t))
+
+	testCases := []struct {
+		name string
+		req  *pb.SubscribeWeatherRequest
+		code codes.Code
+	}{
+		{name: "empty city", req: &pb.SubscribeWeatherRequest{AddCities: []string{" "}}, code: codes.InvalidArgument},
+		{name: "zero interval", req: &pb.SubscribeWeatherRequest{Interval: durationpb.New(0)}, code: codes.InvalidArgument},
+		{name: "rule without metric", req: &pb.SubscribeWeatherRequest{AddRules: []*pb.AlertRule{{
+			Id: "hot", City: "London", Comparison: pb.AlertComparison_ALERT_COMPARISON_ABOVE,
+		}}}, code: codes.InvalidArgument},
+		{name: "rule without id", req: &pb.SubscribeWeatherRequest{AddRules: []*pb.AlertRule{{
+			City: "London", Metric: pb.WeatherMetric_WEATHER_METRIC_TEMPERATURE, Comparison: pb.AlertComparison_ALERT_COMPARISON_ABOVE,
+		}}}, code: codes.InvalidArgument},
+		{name: "too many cities", req: &pb.SubscribeWeatherRequest{AddCities: manyCities(maxCities + 1)}, code: codes.ResourceExhausted},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			stream, err := client.SubscribeWeather(context.Background())
+			require.NoError(t, err)
+			require.NoError(t, stream.Send(tc.req))
+			for err == nil {
+				_, err = stream.Recv()
+			}
+			require.Equal(t, tc.code, status.Code(err))
+		})
+	}
+}
+
+func manyCities(n int) []string {
+	cities := []string{}
+	for i := 0; i < n; i++ {
+		cities = append(cities, fmt.Sprintf("city-%d", i))
+	}
+	return cities
+}
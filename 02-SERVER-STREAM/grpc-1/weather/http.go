@@ -0,0 +1,88 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// HTTPProvider reads the weather from an HTTP service that answers
+// GET {baseURL}/current?city=London with a JSON document such as
+//
+//	{"city": "London", "condition": "rain", "temperatureCelsius": 11.5,
+//	 "humidityPercent": 87, "windSpeedKph": 19, "windDirectionDegrees": 240,
+//	 "observedAt": "2024-07-01T12:00:00Z"}
+//
+// and with 404 Not Found for a city it does not know.
+type HTTPProvider struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewHTTPProvider returns a provider for the service at baseURL, calling it
+// with client, or with a client with a 10s timeout when client is nil
+func NewHTTPProvider(baseURL string, client *http.Client) (*HTTPProvider, error) {
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse base url: %w", err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, fmt.Errorf("base url %q is not an http or https url", baseURL)
+	}
+
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &HTTPProvider{baseURL: strings.TrimSuffix(baseURL, "/"), client: client}, nil
+}
+
+type httpObservation struct {
+	City                 string    `json:"city"`
+	Condition            string    `json:"condition"`
+	TemperatureCelsius   float64   `json:"temperatureCelsius"`
+	HumidityPercent      float64   `json:"humidityPercent"`
+	WindSpeedKph         float64   `json:"windSpeedKph"`
+	WindDirectionDegrees float64   `json:"windDirectionDegrees"`
+	ObservedAt           time.Time `json:"observedAt"`
+}
+
+func (provider *HTTPProvider) Current(ctx context.Context, city string) (Observation, error) {
+	address := provider.baseURL + "/current?" + url.Values{"city": {city}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, address, nil)
+	if err != nil {
+		return Observation{}, fmt.Errorf("cannot create weather request: %w", err)
+	}
+
+	res, err := provider.client.Do(req)
+	if err != nil {
+		return Observation{}, fmt.Errorf("cannot get weather: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return Observation{}, ErrUnknownCity
+	}
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		return Observation{}, fmt.Errorf("cannot get weather: %s: %s", res.Status, strings.TrimSpace(string(body)))
+	}
+
+	var observation httpObservation
+	err = json.NewDecoder(res.Body).Decode(&observation)
+	if err != nil {
+		return Observation{}, fmt.Errorf("cannot decode weather: %w", err)
+	}
+	if observation.City == "" {
+		observation.City = city
+	}
+	if observation.ObservedAt.IsZero() {
+		observation.ObservedAt = time.Now().UTC()
+	}
+
+	return Observation(observation), nil
+}
@@ -0,0 +1,256 @@
+package weather
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// subscriptionBuffer is the number of updates a subscription holds before
+// new ones are dropped, the next poll brings a fresher one anyway
+const subscriptionBuffer = 16
+
+// Update is the result of one poll of a city
+type Update struct {
+	City        string
+	Observation Observation
+	Err         error
+}
+
+// Hub shares the polls of a provider between subscriptions: a city is
+// polled once at the shortest interval of the subscriptions watching it,
+// however many there are, and not at all when none watches it.
+type Hub struct {
+	provider WeatherProvider
+
+	mutex   sync.Mutex
+	pollers map[string]*poller
+}
+
+// poller polls one city for the subscriptions watching it
+type poller struct {
+	city     string
+	interval time.Duration
+	// sent is when each subscription last received an update
+	sent     map[*Subscription]time.Time
+	latest   *Update
+	lastPoll time.Time
+	// wake is signalled when interval changes
+	wake   chan struct{}
+	cancel context.CancelFunc
+}
+
+// NewHub returns a hub polling provider
+func NewHub(provider WeatherProvider) *Hub {
+	return &Hub{provider: provider, pollers: map[string]*poller{}}
+}
+
+// Subscription receives the updates of the cities it watches, at most once
+// per interval for each city
+type Subscription struct {
+	hub      *Hub
+	interval time.Duration
+	pollers  map[string]*poller
+	updates  chan Update
+	closed   bool
+}
+
+// Subscribe returns a subscription that watches no city yet
+func (hub *Hub) Subscribe(interval time.Duration) *Subscription {
+	return &Subscription{
+		hub:      hub,
+		interval: interval,
+		pollers:  map[string]*poller{},
+		updates:  make(chan Update, subscriptionBuffer),
+	}
+}
+
+// CityKey matches city names regardless of case and surrounding spaces
+func CityKey(city string) string {
+	return strings.ToLower(strings.TrimSpace(city))
+}
+
+// Updates returns the channel of updates, it is closed by Close
+func (sub *Subscription) Updates() <-chan Update {
+	return sub.updates
+}
+
+// Cities returns the number of cities sub watches
+func (sub *Subscription) Cities() int {
+	sub.hub.mutex.Lock()
+	defer sub.hub.mutex.Unlock()
+	return len(sub.pollers)
+}
+
+// Watches reports whether sub watches city
+func (sub *Subscription) Watches(city string) bool {
+	sub.hub.mutex.Lock()
+	defer sub.hub.mutex.Unlock()
+	return sub.pollers[CityKey(city)] != nil
+}
+
+// Add watches city. The last update of the city is sent right away when it
+// is already polled for another subscription.
+func (sub *Subscription) Add(city string) {
+	hub := sub.hub
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+
+	key := CityKey(city)
+	if sub.closed || sub.pollers[key] != nil {
+		return
+	}
+
+	p := hub.pollers[key]
+	if p == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		p = &poller{
+			city:     strings.TrimSpace(city),
+			interval: sub.interval,
+			sent:     map[*Subscription]time.Time{},
+			wake:     make(chan struct{}, 1),
+			cancel:   cancel,
+		}
+		hub.pollers[key] = p
+		go hub.poll(ctx, p)
+	}
+
+	p.sent[sub] = time.Time{}
+	sub.pollers[key] = p
+	if p.latest != nil {
+		p.deliver(sub, *p.latest, time.Now())
+	}
+	p.updateInterval()
+}
+
+// Remove stops watching city and reports whether sub watched it
+func (sub *Subscription) Remove(city string) bool {
+	sub.hub.mutex.Lock()
+	defer sub.hub.mutex.Unlock()
+
+	key := CityKey(city)
+	if sub.pollers[key] == nil {
+		return false
+	}
+	sub.hub.leave(sub, key)
+	return true
+}
+
+// SetInterval changes the time between two updates of a city
+func (sub *Subscription) SetInterval(interval time.Duration) {
+	sub.hub.mutex.Lock()
+	defer sub.hub.mutex.Unlock()
+
+	sub.interval = interval
+	for _, p := range sub.pollers {
+		p.updateInterval()
+	}
+}
+
+// Close stops watching every city and closes the updates channel
+func (sub *Subscription) Close() {
+	sub.hub.mutex.Lock()
+	defer sub.hub.mutex.Unlock()
+
+	if sub.closed {
+		return
+	}
+	for key := range sub.pollers {
+		sub.hub.leave(sub, key)
+	}
+	sub.closed = true
+	close(sub.updates)
+}
+
+// leave removes sub from the poller of key, stopping the poller when it has
+// no subscription left. The caller holds the mutex.
+func (hub *Hub) leave(sub *Subscription, key string) {
+	p := sub.pollers[key]
+	delete(sub.pollers, key)
+	delete(p.sent, sub)
+
+	if len(p.sent) == 0 {
+		p.cancel()
+		delete(hub.pollers, key)
+		return
+	}
+	p.updateInterval()
+}
+
+func (hub *Hub) poll(ctx context.Context, p *poller) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.wake:
+			// the interval changed, wait for the rest of the new one
+			hub.mutex.Lock()
+			wait := time.Until(p.lastPoll.Add(p.interval))
+			hub.mutex.Unlock()
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(max(wait, 0))
+			continue
+		case <-timer.C:
+		}
+
+		observation, err := hub.provider.Current(ctx, p.city)
+		if ctx.Err() != nil {
+			return
+		}
+
+		hub.mutex.Lock()
+		now := time.Now()
+		update := Update{City: p.city, Observation: observation, Err: err}
+		p.latest = &update
+		p.lastPoll = now
+		for sub, sent := range p.sent {
+			// half an interval of slack keeps a subscription polled at its own
+			// interval from skipping updates that arrive a little early
+			if now.Sub(sent) >= sub.interval-p.interval/2 {
+				p.deliver(sub, update, now)
+			}
+		}
+		interval := p.interval
+		hub.mutex.Unlock()
+
+		timer.Reset(interval)
+	}
+}
+
+// deliver sends update to sub, dropping it when sub is full. The caller holds the mutex.
+func (p *poller) deliver(sub *Subscription, update Update, now time.Time) {
+	select {
+	case sub.updates <- update:
+		p.sent[sub] = now
+	default:
+	}
+}
+
+// updateInterval sets the interval of p to the shortest of its
+// subscriptions. The caller holds the mutex.
+func (p *poller) updateInterval() {
+	interval := time.Duration(0)
+	for sub := range p.sent {
+		if interval == 0 || sub.interval < interval {
+			interval = sub.interval
+		}
+	}
+	if interval == 0 || interval == p.interval {
+		return
+	}
+
+	p.interval = interval
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
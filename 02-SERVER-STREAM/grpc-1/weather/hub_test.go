@@ -0,0 +1,148 @@
+package weather_test
+
+import (
+	"context"
+	"grpc-1/weather"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// countingProvider counts the polls of each city
+type countingProvider struct {
+	weather.WeatherProvider
+
+	mutex sync.Mutex
+	polls map[string]int
+}
+
+func newCountingProvider() *countingProvider {
+	return &countingProvider{
+		WeatherProvider: weather.NewSimulatedProvider(nil, time.Millisecond),
+		polls:           map[string]int{},
+	}
+}
+
+func (provider *countingProvider) Current(ctx context.Context, city string) (weather.Observation, error) {
+	provider.mutex.Lock()
+	provider.polls[strings.ToLower(city)]++
+	provider.mutex.Unlock()
+	return provider.WeatherProvider.Current(ctx, city)
+}
+
+func (provider *countingProvider) count(city string) int {
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+	return provider.polls[city]
+}
+
+// receive returns the next n updates of sub
+func receive(t *testing.T, sub *weather.Subscription, n int) []weather.Update {
+	updates := []weather.Update{}
+	timeout := time.After(5 * time.Second)
+	for len(updates) < n {
+		select {
+		case update := <-sub.Updates():
+			require.NoError(t, update.Err)
+			updates = append(updates, update)
+		case <-timeout:
+			t.Fatalf("received %d updates, expected %d", len(updates), n)
+		}
+	}
+	return updates
+}
+
+func TestHubSharesPolls(t *testing.T) {
+	t.Parallel()
+
+	provider := newCountingProvider()
+	hub := weather.NewHub(provider)
+
+	first := hub.Subscribe(20 * time.Millisecond)
+	defer first.Close()
+	second := hub.Subscribe(20 * time.Millisecond)
+	defer second.Close()
+
+	first.Add("London")
+	second.Add(" london ")
+	require.True(t, second.Watches("LONDON"))
+
+	receive(t, first, 5)
+	receive(t, second, 5)
+
+	// two subscriptions with one poll each would need 10 polls
+	require.Less(t, provider.count("london"), 8)
+}
+
+func TestHubSubscription(t *testing.T) {
+	t.Parallel()
+
+	provider := newCountingProvider()
+	hub := weather.NewHub(provider)
+
+	sub := hub.Subscribe(10 * time.Millisecond)
+	sub.Add("London")
+	sub.Add("Paris")
+	require.Equal(t, 2, sub.Cities())
+
+	cities := map[string]bool{}
+	for _, update := range receive(t, sub, 6) {
+		cities[update.City] = true
+	}
+	require.Equal(t, map[string]bool{"London": true, "Paris": true}, cities)
+
+	require.True(t, sub.Remove("paris"))
+	require.False(t, sub.Remove("paris"))
+	drain(sub)
+	receive(t, sub, 2)
+	polls := provider.count("paris")
+	for _, update := range receive(t, sub, 3) {
+		require.Equal(t, "London", update.City)
+	}
+	require.Equal(t, polls, provider.count("paris"))
+
+	// a longer interval slows the polls down
+	sub.SetInterval(time.Hour)
+	drain(sub)
+	polls = provider.count("london")
+	time.Sleep(50 * time.Millisecond)
+	require.LessOrEqual(t, provider.count("london"), polls+1)
+
+	sub.Close()
+	_, open := <-sub.Updates()
+	require.False(t, open)
+}
+
+func TestHubSubscriptionIntervals(t *testing.T) {
+	t.Parallel()
+
+	hub := weather.NewHub(newCountingProvider())
+
+	fast := hub.Subscribe(10 * time.Millisecond)
+	defer fast.Close()
+	slow := hub.Subscribe(time.Hour)
+	defer slow.Close()
+
+	fast.Add("London")
+	receive(t, fast, 1)
+
+	// a new subscription gets the last update of a polled city right away,
+	// then no more until its own interval passes
+	slow.Add("London")
+	receive(t, slow, 1)
+	receive(t, fast, 5)
+	require.Empty(t, slow.Updates())
+}
+
+func drain(sub *weather.Subscription) {
+	for {
+		select {
+		case <-sub.Updates():
+		default:
+			return
+		}
+	}
+}
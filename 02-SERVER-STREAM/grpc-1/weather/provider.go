@@ -0,0 +1,26 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrUnknownCity is returned by a provider that has no weather for a city
+var ErrUnknownCity = errors.New("unknown city")
+
+// Observation is the weather in a city at one point in time
+type Observation struct {
+	City                 string
+	Condition            string
+	TemperatureCelsius   float64
+	HumidityPercent      float64
+	WindSpeedKph         float64
+	WindDirectionDegrees float64
+	ObservedAt           time.Time
+}
+
+// WeatherProvider returns the current weather of a city
+type WeatherProvider interface {
+	Current(ctx context.Context, city string) (Observation, error)
+}
@@ -0,0 +1,87 @@
+package weather_test
+
+import (
+	"context"
+	"grpc-1/weather"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulatedProvider(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.July, 1, 15, 4, 5, 0, time.UTC)
+	provider := weather.NewSimulatedProvider(func() time.Time { return now }, time.Minute)
+	ctx := context.Background()
+
+	london, err := provider.Current(ctx, "London")
+	require.NoError(t, err)
+	require.Equal(t, "London", london.City)
+	require.Equal(t, now.Truncate(time.Minute), london.ObservedAt)
+	require.GreaterOrEqual(t, london.HumidityPercent, 0.0)
+	require.LessOrEqual(t, london.HumidityPercent, 100.0)
+	require.GreaterOrEqual(t, london.WindSpeedKph, 0.0)
+	require.Contains(t, []string{"sunny", "cloudy", "windy", "rain"}, london.Condition)
+
+	// the same city at the same step always has the same weather
+	again, err := weather.NewSimulatedProvider(func() time.Time { return now.Add(30 * time.Second) }, time.Minute).Current(ctx, "London")
+	require.NoError(t, err)
+	require.Equal(t, london, again)
+
+	paris, err := provider.Current(ctx, "Paris")
+	require.NoError(t, err)
+	require.NotEqual(t, london.TemperatureCelsius, paris.TemperatureCelsius)
+
+	_, err = provider.Current(ctx, " ")
+	require.ErrorIs(t, err, weather.ErrUnknownCity)
+}
+
+func TestHTTPProvider(t *testing.T) {
+	t.Parallel()
+
+	service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path != "/v1/current":
+			http.NotFound(w, r)
+		case r.URL.Query().Get("city") == "São Paulo":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"city": "São Paulo", "condition": "rain", "temperatureCelsius": 21.5,
+				"humidityPercent": 88, "windSpeedKph": 12.3, "windDirectionDegrees": 90,
+				"observedAt": "2024-07-01T12:00:00Z"}`))
+		case r.URL.Query().Get("city") == "Error":
+			http.Error(w, "upstream is down", http.StatusBadGateway)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(service.Close)
+
+	provider, err := weather.NewHTTPProvider(service.URL+"/v1/", nil)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	observation, err := provider.Current(ctx, "São Paulo")
+	require.NoError(t, err)
+	require.Equal(t, weather.Observation{
+		City:                 "São Paulo",
+		Condition:            "rain",
+		TemperatureCelsius:   21.5,
+		HumidityPercent:      88,
+		WindSpeedKph:         12.3,
+		WindDirectionDegrees: 90,
+		ObservedAt:           time.Date(2024, time.July, 1, 12, 0, 0, 0, time.UTC),
+	}, observation)
+
+	_, err = provider.Current(ctx, "Atlantis")
+	require.ErrorIs(t, err, weather.ErrUnknownCity)
+
+	_, err = provider.Current(ctx, "Error")
+	require.ErrorContains(t, err, "upstream is down")
+
+	_, err = weather.NewHTTPProvider("ftp://example.com", nil)
+	require.Error(t, err)
+}
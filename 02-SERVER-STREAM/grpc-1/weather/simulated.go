@@ -0,0 +1,91 @@
+package weather
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"strings"
+	"time"
+)
+
+// SimulatedProvider makes up the weather. An observation only depends on the
+// city and the time truncated to the step, so the same clock always gives
+// the same weather.
+type SimulatedProvider struct {
+	now  func() time.Time
+	step time.Duration
+}
+
+// NewSimulatedProvider returns a provider whose weather changes every step,
+// reading the time from now, or from the system clock when now is nil
+func NewSimulatedProvider(now func() time.Time, step time.Duration) *SimulatedProvider {
+	if now == nil {
+		now = time.Now
+	}
+	if step <= 0 {
+		step = time.Second
+	}
+	return &SimulatedProvider{now: now, step: step}
+}
+
+func (provider *SimulatedProvider) Current(ctx context.Context, city string) (Observation, error) {
+	if err := ctx.Err(); err != nil {
+		return Observation{}, err
+	}
+	city = strings.TrimSpace(city)
+	if city == "" {
+		return Observation{}, ErrUnknownCity
+	}
+
+	at := provider.now().UTC().Truncate(provider.step)
+	step := at.UnixNano() / int64(provider.step)
+	climate := noise(city, 0)
+
+	// the temperature peaks mid-afternoon and bottoms out before dawn
+	hour := float64(at.Hour()) + float64(at.Minute())/60
+	daily := math.Sin((hour - 9) / 24 * 2 * math.Pi)
+	temperature := 5 + 20*climate + 6*daily + 2*(noise(city, step)-0.5)
+	humidity := clamp(45+40*noise(city, step+1)-10*daily, 0, 100)
+	wind := 40 * noise(city, step+2) * noise(city, step+3)
+
+	return Observation{
+		City:                 city,
+		Condition:            condition(humidity, wind),
+		TemperatureCelsius:   round(temperature),
+		HumidityPercent:      round(humidity),
+		WindSpeedKph:         round(wind),
+		WindDirectionDegrees: math.Floor(360 * noise(city, step+4)),
+		ObservedAt:           at,
+	}, nil
+}
+
+func condition(humidity float64, wind float64) string {
+	switch {
+	case humidity > 80:
+		return "rain"
+	case wind > 25:
+		return "windy"
+	case humidity > 60:
+		return "cloudy"
+	}
+	return "sunny"
+}
+
+// noise returns a number in [0, 1) derived from city and n
+func noise(city string, n int64) float64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(strings.ToLower(city)))
+	for i := 0; i < 8; i++ {
+		hash.Write([]byte{byte(n >> (8 * i))})
+	}
+	return float64(hash.Sum64()>>11) / (1 << 53)
+}
+
+func clamp(value float64, min float64, max float64) float64 {
+	return math.Max(min, math.Min(max, value))
+}
+
+// round rounds to one decimal
+func round(value float64) float64 {
+	return math.Round(value*10) / 10
+}
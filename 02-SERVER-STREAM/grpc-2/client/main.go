@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"grpc-2/pb"
+	"io"
 	"log"
 
 	"google.golang.org/grpc"
@@ -17,20 +18,28 @@ func main() {
 	defer conn.Close()
 	c := pb.NewProcessesClient(conn)
 
-	req := &pb.ProcessRequest{}
+	// the ten processes using the most CPU
+	req := &pb.ProcessRequest{
+		SortBy: pb.ProcessSortField_PROCESS_SORT_CPU,
+		Limit:  10,
+	}
 	stream, err := c.GetProcessesInfo(context.Background(), req)
 	if err != nil {
 		log.Fatalf("Error calling GetProcessesInfo : %v", err)
 	}
 	for {
 		resp, err := stream.Recv()
-		if err != nil {
+		if err == io.EOF {
 			break
 		}
-		log.Println(" Process Id:",resp.ProcessId)
-		log.Println(" Process Name:",resp.ProcessName)
-		log.Printf(" Cpu Usage: %.4f %",resp.CpuUsage)
-		log.Printf(" Memory Usage: %.2f %s",resp.MemoryUsage, resp.MemUnit)
+		if err != nil {
+			log.Fatalf("Error receiving : %v", err)
+		}
+		log.Println(" Process Id:", resp.ProcessId)
+		log.Println(" Process Name:", resp.ProcessName)
+		log.Println(" User:", resp.User)
+		log.Printf(" Cpu Usage: %.4f %%", resp.CpuUsage)
+		log.Printf(" Memory Usage: %.2f %s", resp.MemoryUsage, resp.MemUnit)
 		println("")
 	}
-}
\ No newline at end of file
+}
@@ -1,27 +1,36 @@
 package main
 
 import (
+	"flag"
 	"grpc-2/pb"
 	"log"
 	"net"
+	"runtime"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
 
 func main() {
-	listener, err := net.Listen("tcp", ":50051")	
+	address := flag.String("address", ":50051", "address to listen on")
+	workers := flag.Int("workers", runtime.NumCPU(), "number of processes read at once, shared by all requests")
+	flag.Parse()
+
+	if *workers < 1 {
+		log.Fatalf("workers must be at least 1, got %d", *workers)
+	}
+
+	listener, err := net.Listen("tcp", *address)
 	if err != nil {
 		log.Fatalf("Error listening : %v", err)
 	}
 
 	s := grpc.NewServer()
-	pb.RegisterProcessesServer(s, &server{})
+	pb.RegisterProcessesServer(s, newServer(*workers))
 
 	reflection.Register(s)
 
-	if err  := s.Serve(listener); err != nil {
+	if err := s.Serve(listener); err != nil {
 		log.Fatalf("Error serving : %v", err)
 	}
 }
-
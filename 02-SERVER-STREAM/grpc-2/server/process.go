@@ -1,17 +1,109 @@
 package main
 
 import (
-	"log"
+	"context"
+	"fmt"
+	"sync"
 
 	"github.com/shirou/gopsutil/v3/process"
 )
 
-func getProcessInfo() ([]*process.Process, error) {
+// processInfo holds the stats of a process
+type processInfo struct {
+	pid      int32
+	name     string
+	user     string
+	cpuUsage float64
+	rss      uint64
+}
+
+func (info processInfo) memoryMB() float64 {
+	return float64(info.rss) / 1024.0 / 1024.0
+}
+
+// collectProcesses reads the stats of the processes matching query. Each read
+// takes a slot of readers, which is shared by every request, so that at most
+// cap(readers) processes are read at once however many requests run.
+// Processes that exit or cannot be read while they are collected are left out.
+func collectProcesses(ctx context.Context, readers chan struct{}, query processQuery) ([]processInfo, error) {
+	processes, err := process.ProcessesWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list processes: %w", err)
+	}
+
+	jobs := make(chan *process.Process)
+	results := make(chan processInfo)
+
+	go func() {
+		defer close(jobs)
+		for _, p := range processes {
+			select {
+			case jobs <- p:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < cap(readers); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range jobs {
+				select {
+				case readers <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+				info, ok := readProcess(ctx, p, query)
+				<-readers
+
+				if ok {
+					results <- info
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
 
-	processes, err := process.Processes()
+	infos := []processInfo{}
+	for info := range results {
+		infos = append(infos, info)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return infos, nil
+}
+
+// readProcess reads the stats of p and reports whether it matches query.
+// The cheap filters run first, to skip reading the stats of most processes.
+func readProcess(ctx context.Context, p *process.Process, query processQuery) (processInfo, bool) {
+	name, err := p.NameWithContext(ctx)
+	if err != nil || !query.matchesName(name) {
+		return processInfo{}, false
+	}
+
+	// the owner of a process is not always known, such as in containers
+	// without its user in /etc/passwd
+	user, _ := p.UsernameWithContext(ctx)
+	if !query.matchesUser(user) {
+		return processInfo{}, false
+	}
+
+	cpuUsage, err := p.CPUPercentWithContext(ctx)
+	if err != nil {
+		return processInfo{}, false
+	}
+	memory, err := p.MemoryInfoWithContext(ctx)
 	if err != nil {
-		log.Fatalf("Error retriving processes : %v", err)
+		return processInfo{}, false
 	}
 
-	return processes, nil
+	info := processInfo{pid: p.Pid, name: name, user: user, cpuUsage: cpuUsage, rss: memory.RSS}
+	return info, query.matchesUsage(info)
 }
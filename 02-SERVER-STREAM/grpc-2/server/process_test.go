@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectProcesses(t *testing.T) {
+	t.Parallel()
+
+	executable, err := os.Executable()
+	require.NoError(t, err)
+	name := filepath.Base(executable)
+	ctx := context.Background()
+
+	all, err := collectProcesses(ctx, make(chan struct{}, 4), processQuery{})
+	require.NoError(t, err)
+	require.NotEmpty(t, all)
+
+	// the test binary finds itself by name
+	query := processQuery{namePattern: name[:len(name)-1] + "?"}
+	processes, err := collectProcesses(ctx, make(chan struct{}, 2), query)
+	require.NoError(t, err)
+	pids := []int32{}
+	for _, process := range processes {
+		require.Equal(t, name, process.name)
+		pids = append(pids, process.pid)
+	}
+	require.Contains(t, pids, int32(os.Getpid()))
+
+	processes, err = collectProcesses(ctx, make(chan struct{}, 2), processQuery{namePattern: "no such process *"})
+	require.NoError(t, err)
+	require.Empty(t, processes)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = collectProcesses(canceled, make(chan struct{}, 2), processQuery{})
+	require.ErrorIs(t, err, context.Canceled)
+
+	// no process is read while every slot is taken by other requests
+	readers := make(chan struct{}, 1)
+	readers <- struct{}{}
+	waiting, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = collectProcesses(waiting, readers, processQuery{})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"grpc-2/pb"
+	"path"
+	"sort"
+	"strings"
+)
+
+// processQuery is a validated ProcessRequest
+type processQuery struct {
+	namePattern    string
+	user           string
+	minCPUUsage    float64
+	minMemoryUsage float64
+	sortBy         pb.ProcessSortField
+	ascending      bool
+	limit          int
+}
+
+func newProcessQuery(req *pb.ProcessRequest) (processQuery, error) {
+	query := processQuery{
+		namePattern:    strings.ToLower(req.GetNamePattern()),
+		user:           req.GetUser(),
+		minCPUUsage:    float64(req.GetMinCpuUsage()),
+		minMemoryUsage: float64(req.GetMinMemoryUsage()),
+		sortBy:         req.GetSortBy(),
+		ascending:      req.GetAscending(),
+		limit:          int(req.GetLimit()),
+	}
+
+	// path.Match only reports a bad pattern when it gets that far in the
+	// name, so check the pattern against itself
+	_, err := path.Match(query.namePattern, query.namePattern)
+	if err != nil {
+		return processQuery{}, fmt.Errorf("invalid name pattern %q: %w", req.GetNamePattern(), err)
+	}
+	if query.minCPUUsage < 0 || query.minMemoryUsage < 0 {
+		return processQuery{}, fmt.Errorf("minimum usages must not be negative")
+	}
+	if _, ok := pb.ProcessSortField_name[int32(query.sortBy)]; !ok {
+		return processQuery{}, fmt.Errorf("unknown sort field %d", query.sortBy)
+	}
+
+	return query, nil
+}
+
+func (query processQuery) matchesName(name string) bool {
+	if query.namePattern == "" {
+		return true
+	}
+	matched, _ := path.Match(query.namePattern, strings.ToLower(name))
+	return matched
+}
+
+func (query processQuery) matchesUser(user string) bool {
+	return query.user == "" || query.user == user
+}
+
+func (query processQuery) matchesUsage(info processInfo) bool {
+	return info.cpuUsage >= query.minCPUUsage && info.memoryMB() >= query.minMemoryUsage
+}
+
+// sortAndLimit sorts processes as query asks and keeps the first limit of
+// them. Ties, and processes without a sort field, are ordered by id.
+func (query processQuery) sortAndLimit(processes []processInfo) []processInfo {
+	less := func(a, b processInfo) bool { return a.pid < b.pid }
+	switch query.sortBy {
+	case pb.ProcessSortField_PROCESS_SORT_CPU:
+		less = byUsage(query.ascending, func(info processInfo) float64 { return info.cpuUsage })
+	case pb.ProcessSortField_PROCESS_SORT_RSS:
+		less = byUsage(query.ascending, func(info processInfo) float64 { return float64(info.rss) })
+	}
+
+	sort.Slice(processes, func(i, j int) bool { return less(processes[i], processes[j]) })
+	if query.limit > 0 && len(processes) > query.limit {
+		processes = processes[:query.limit]
+	}
+	return processes
+}
+
+func byUsage(ascending bool, usage func(processInfo) float64) func(a, b processInfo) bool {
+	return func(a, b processInfo) bool {
+		if usage(a) != usage(b) {
+			return (usage(a) < usage(b)) == ascending
+		}
+		return a.pid < b.pid
+	}
+}
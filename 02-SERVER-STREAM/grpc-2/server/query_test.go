@@ -0,0 +1,76 @@
+package main
+
+import (
+	"grpc-2/pb"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProcessQuery(t *testing.T) {
+	t.Parallel()
+
+	invalid := []*pb.ProcessRequest{
+		{NamePattern: "[a-"},
+		{MinCpuUsage: -1},
+		{MinMemoryUsage: -0.5},
+		{SortBy: 9},
+	}
+	for _, req := range invalid {
+		_, err := newProcessQuery(req)
+		require.Error(t, err, "request %v", req)
+	}
+
+	query, err := newProcessQuery(&pb.ProcessRequest{NamePattern: "Post*", User: "postgres", MinCpuUsage: 1.5})
+	require.NoError(t, err)
+	require.True(t, query.matchesName("postgres"))
+	require.True(t, query.matchesName("POSTMASTER"))
+	require.False(t, query.matchesName("apostrophe"))
+	require.True(t, query.matchesUser("postgres"))
+	require.False(t, query.matchesUser("root"))
+	require.True(t, query.matchesUsage(processInfo{cpuUsage: 1.5}))
+	require.False(t, query.matchesUsage(processInfo{cpuUsage: 1.4}))
+}
+
+func TestSortAndLimit(t *testing.T) {
+	t.Parallel()
+
+	processes := func() []processInfo {
+		return []processInfo{
+			{pid: 30, cpuUsage: 5, rss: 100},
+			{pid: 10, cpuUsage: 20, rss: 300},
+			{pid: 40, cpuUsage: 5, rss: 400},
+			{pid: 20, cpuUsage: 1, rss: 200},
+		}
+	}
+	pids := func(processes []processInfo) []int32 {
+		result := []int32{}
+		for _, process := range processes {
+			result = append(result, process.pid)
+		}
+		return result
+	}
+
+	testCases := []struct {
+		name     string
+		req      *pb.ProcessRequest
+		expected []int32
+	}{
+		{name: "by id", req: &pb.ProcessRequest{}, expected: []int32{10, 20, 30, 40}},
+		{name: "top cpu", req: &pb.ProcessRequest{SortBy: pb.ProcessSortField_PROCESS_SORT_CPU}, expected: []int32{10, 30, 40, 20}},
+		{name: "least cpu", req: &pb.ProcessRequest{SortBy: pb.ProcessSortField_PROCESS_SORT_CPU, Ascending: true}, expected: []int32{20, 30, 40, 10}},
+		{name: "top 2 rss", req: &pb.ProcessRequest{SortBy: pb.ProcessSortField_PROCESS_SORT_RSS, Limit: 2}, expected: []int32{40, 10}},
+		{name: "limit above count", req: &pb.ProcessRequest{Limit: 10}, expected: []int32{10, 20, 30, 40}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			query, err := newProcessQuery(tc.req)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, pids(query.sortAndLimit(processes())))
+		})
+	}
+}
@@ -2,50 +2,49 @@ package main
 
 import (
 	"grpc-2/pb"
-	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type server struct {
 	pb.UnimplementedProcessesServer
+
+	// readers bounds the number of processes read at once, across all requests
+	readers chan struct{}
 }
 
-func (s *server) GetProcessesInfo(request *pb.ProcessRequest, stream pb.Processes_GetProcessesInfoServer) error {
+func newServer(workers int) *server {
+	return &server{readers: make(chan struct{}, workers)}
+}
 
-	processes, err := getProcessInfo()
+func (s *server) GetProcessesInfo(request *pb.ProcessRequest, stream pb.Processes_GetProcessesInfoServer) error {
+	ctx := stream.Context()
+	query, err := newProcessQuery(request)
 	if err != nil {
-		return err
+		return status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	for _, process := range processes {
-		processName, err := process.Name()
-		if err != nil {
-			continue
-		}
-
-		cpuUsage, err := process.CPUPercent()
-		if err != nil {
-			continue
-		}
-
-		memUsage, err := process.MemoryInfo()
-		if err != nil {
-			continue
+	processes, err := collectProcesses(ctx, s.readers, query)
+	if err != nil {
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
 		}
+		return status.Errorf(codes.Internal, "cannot collect processes: %v", err)
+	}
 
-		memUsageInMB := float32(memUsage.RSS) / 1024.0 / 1024.0
-
+	for _, process := range query.sortAndLimit(processes) {
 		processResponse := &pb.ProcessResponse{
-			ProcessId:   uint32(process.Pid),
-			ProcessName: processName,
-			CpuUsage:    float32(cpuUsage),
-			MemoryUsage: memUsageInMB,
+			ProcessId:   uint32(process.pid),
+			ProcessName: process.name,
+			CpuUsage:    float32(process.cpuUsage),
+			MemoryUsage: float32(process.memoryMB()),
 			MemUnit:     "MB",
+			User:        process.user,
 		}
 		if err := stream.Send(processResponse); err != nil {
 			return err
 		}
-
-		time.Sleep(time.Millisecond * 100)
 	}
 	return nil
 }
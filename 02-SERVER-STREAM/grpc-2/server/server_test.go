@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"grpc-2/pb"
+	"io"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestGetProcessesInfo(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t)
+
+	testCases := []struct {
+		name  string
+		req   *pb.ProcessRequest
+		check func(t *testing.T, processes []*pb.ProcessResponse)
+	}{
+		{
+			name: "top_memory",
+			req:  &pb.ProcessRequest{SortBy: pb.ProcessSortField_PROCESS_SORT_RSS, Limit: 3},
+			check: func(t *testing.T, processes []*pb.ProcessResponse) {
+				require.NotEmpty(t, processes)
+				require.LessOrEqual(t, len(processes), 3)
+				for i := 1; i < len(processes); i++ {
+					require.GreaterOrEqual(t, processes[i-1].MemoryUsage, processes[i].MemoryUsage)
+				}
+			},
+		},
+		{
+			name: "cpu_ascending",
+			req:  &pb.ProcessRequest{SortBy: pb.ProcessSortField_PROCESS_SORT_CPU, Ascending: true, Limit: 5},
+			check: func(t *testing.T, processes []*pb.ProcessResponse) {
+				require.NotEmpty(t, processes)
+				require.LessOrEqual(t, len(processes), 5)
+				for i := 1; i < len(processes); i++ {
+					require.LessOrEqual(t, processes[i-1].CpuUsage, processes[i].CpuUsage)
+				}
+			},
+		},
+		{
+			name: "by_id",
+			req:  &pb.ProcessRequest{},
+			check: func(t *testing.T, processes []*pb.ProcessResponse) {
+				pids := []uint32{}
+				for i, process := range processes {
+					if i > 0 {
+						require.Less(t, processes[i-1].ProcessId, process.ProcessId)
+					}
+					require.Equal(t, "MB", process.MemUnit)
+					pids = append(pids, process.ProcessId)
+				}
+				require.Contains(t, pids, uint32(os.Getpid()))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			processes, err := receiveProcesses(client, tc.req)
+			require.NoError(t, err)
+			tc.check(t, processes)
+		})
+	}
+
+	invalid := []*pb.ProcessRequest{
+		{NamePattern: "[a-"},
+		{MinCpuUsage: -1},
+		{SortBy: 9},
+	}
+	for _, req := range invalid {
+		_, err := receiveProcesses(client, req)
+		require.Error(t, err)
+		require.Equal(t, codes.InvalidArgument, status.Code(err), "request %v", req)
+	}
+}
+
+// receiveProcesses calls GetProcessesInfo and reads the whole stream
+func receiveProcesses(client pb.ProcessesClient, req *pb.ProcessRequest) ([]*pb.ProcessResponse, error) {
+	stream, err := client.GetProcessesInfo(context.Background(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	processes := []*pb.ProcessResponse{}
+	for {
+		process, err := stream.Recv()
+		if err == io.EOF {
+			return processes, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		processes = append(processes, process)
+	}
+}
+
+// newTestClient serves the process service over an in-process bufconn
+// listener. The connection is closed and the server stopped when the test
+// finishes.
+func newTestClient(t *testing.T) pb.ProcessesClient {
+	s := grpc.NewServer()
+	pb.RegisterProcessesServer(s, newServer(4))
+
+	listener := bufconn.Listen(1 << 20)
+	served := make(chan error, 1)
+	go func() {
+		served <- s.Serve(listener)
+	}()
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		conn.Close()
+		s.Stop()
+		require.NoError(t, <-served)
+	})
+
+	return pb.NewProcessesClient(conn)
+}